@@ -0,0 +1,217 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// Argument serialization formats for SetArgFormat
+const (
+	// ArgFormatLegacy sends field arguments as the literal argument maps, with non-null
+	// and list types encoded in type strings like "[String!]!"
+	ArgFormatLegacy = 1
+	// ArgFormatStructured sends field arguments as a list of self-describing definitions,
+	// see serializeArgDefinition
+	ArgFormatStructured = 2
+)
+
+// SetArgFormat selects how field arguments are serialized in SchemaRegister. The default is
+// ArgFormatLegacy; hosts that understand structured arguments can opt into ArgFormatStructured.
+// Fields serialized in the structured format carry "argFormat": 2 so the host can tell them apart.
+func (p *Plugin) SetArgFormat(format int) {
+	p.argFormat.Store(int32(format))
+
+	p.mu.Lock()
+	p.schemaCache = nil
+	p.mu.Unlock()
+}
+
+// structuredArgs reports whether field arguments use ArgFormatStructured
+func (p *Plugin) structuredArgs() bool {
+	return p.argFormat.Load() == ArgFormatStructured
+}
+
+// DefaultArg sets the value used when the client omits the argument
+func DefaultArg(arg map[string]interface{}, value interface{}) map[string]interface{} {
+	arg["defaultValue"] = value
+	return arg
+}
+
+// argDefaultValue returns the value set with DefaultArg, if any
+func argDefaultValue(argDef interface{}) (interface{}, bool) {
+	definition, ok := argDef.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	defaultValue, exists := definition["defaultValue"]
+	return defaultValue, exists && defaultValue != nil
+}
+
+// serializeArgDefinitions converts a field's argument map to a list of structured argument
+// definitions sorted by name. The "objectType" entry that object-returning fields carry is
+// not an argument and is returned separately.
+func (impl *pluginImpl) serializeArgDefinitions(args map[string]interface{}) ([]interface{}, interface{}) {
+	var objectType interface{}
+	names := make([]string, 0, len(args))
+	for name, value := range args {
+		if definition, ok := value.(map[string]interface{}); ok && name == "objectType" && definition["typeName"] != nil {
+			objectType = impl.serializeValue(impl.plugin.namespacedInlineObjectType(definition))
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	definitions := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		definitions = append(definitions, impl.serializeArgDefinition(name, args[name]))
+	}
+	return definitions, objectType
+}
+
+// serializeArgDefinition converts one argument to the structured shape:
+// {name, type, description, required, defaultValue, ...}. The type is a type definition
+// (kind/name/ofType) instead of a string, so "[Int!]!" becomes non_null(list(non_null(Int))).
+// Object properties are serialized recursively under "fields".
+func (impl *pluginImpl) serializeArgDefinition(name string, value interface{}) map[string]interface{} {
+	definition, ok := value.(map[string]interface{})
+	if !ok {
+		// Type definitions or literal values pass through as the argument's type
+		return map[string]interface{}{
+			"name": name,
+			"type": impl.serializeValue(value),
+		}
+	}
+
+	typeString := argTypeString(definition)
+	description, _ := definition["description"].(string)
+	result := map[string]interface{}{
+		"name":        name,
+		"type":        impl.serializeTypeDefinition(parseArgTypeString(typeString)),
+		"description": description,
+		"required":    strings.HasSuffix(typeString, "!"),
+	}
+
+	if defaultValue, exists := definition["defaultValue"]; exists {
+		result["defaultValue"] = impl.serializeValue(defaultValue)
+		// An argument with a default can be omitted even when non-null
+		result["required"] = false
+	}
+	if deprecated, _ := definition["deprecated"].(bool); deprecated {
+		result["deprecated"] = true
+		result["deprecationReason"] = definition["deprecationReason"]
+	}
+	for _, key := range []string{"minItems", "maxItems", "transform"} {
+		if constraint, exists := definition[key]; exists {
+			result[key] = constraint
+		}
+	}
+	if properties, ok := definition["properties"].(map[string]interface{}); ok {
+		fields, _ := impl.serializeArgDefinitions(properties)
+		result["fields"] = fields
+	}
+
+	return result
+}
+
+// argTypeString returns an argument's type in the "[String!]!" notation. "type" is usually a
+// type string, but a structured type (a GraphQLTypeDefinition, or its serialized
+// kind/name/ofType map) is accepted there too; "typeDef" is used when "type" is absent.
+func argTypeString(argDef map[string]interface{}) string {
+	if argType, exists := argDef["type"]; exists && argType != nil {
+		return typeDefinitionString(argType)
+	}
+	return typeDefinitionString(argDef["typeDef"])
+}
+
+// typeDefinitionString renders a type definition or type string in the "[String!]!" notation
+func typeDefinitionString(typeDef interface{}) string {
+	switch t := typeDef.(type) {
+	case string:
+		return t
+	case *GraphQLTypeDefinition:
+		if t != nil {
+			return typeDefinitionString(*t)
+		}
+	case GraphQLTypeDefinition:
+		switch t.Kind {
+		case "non_null":
+			if t.OfType != nil {
+				return typeDefinitionString(*t.OfType) + "!"
+			}
+		case "list":
+			if t.OfType != nil {
+				return "[" + typeDefinitionString(*t.OfType) + "]"
+			}
+		}
+		if t.ScalarType != "" {
+			return t.ScalarType
+		}
+		return t.Name
+	case map[string]interface{}:
+		kind, _ := t["kind"].(string)
+		switch kind {
+		case "non_null":
+			return typeDefinitionString(t["ofType"]) + "!"
+		case "list":
+			return "[" + typeDefinitionString(t["ofType"]) + "]"
+		}
+		if scalarType, _ := t["scalarType"].(string); scalarType != "" {
+			return scalarType
+		}
+		name, _ := t["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// legacyArgs returns the arguments in the form ArgFormatLegacy hosts expect: structured types
+// are rendered as type strings, and object type references, including those in object
+// properties and inline "objectType" definitions, carry the type namespace
+func (impl *pluginImpl) legacyArgs(args map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		definition, ok := value.(map[string]interface{})
+		if !ok {
+			result[name] = value
+			continue
+		}
+		if name == "objectType" && definition["typeName"] != nil {
+			result[name] = impl.plugin.namespacedInlineObjectType(definition)
+			continue
+		}
+
+		converted := make(map[string]interface{}, len(definition))
+		for key, val := range definition {
+			converted[key] = val
+		}
+		// Legacy hosts only read the type string
+		delete(converted, "typeDef")
+		if typeString := argTypeString(definition); typeString != "" {
+			converted["type"] = impl.plugin.namespacedTypeString(typeString)
+		}
+		if properties, ok := definition["properties"].(map[string]interface{}); ok {
+			converted["properties"] = impl.legacyArgs(properties)
+		}
+		result[name] = converted
+	}
+	return result
+}
+
+// parseArgTypeString converts a GraphQL type string such as "[String!]!" to a type definition
+func parseArgTypeString(typeString string) GraphQLTypeDefinition {
+	typeString = strings.TrimSpace(typeString)
+	if strings.HasSuffix(typeString, "!") {
+		inner := parseArgTypeString(strings.TrimSuffix(typeString, "!"))
+		return createNonNullType(inner)
+	}
+	if strings.HasPrefix(typeString, "[") && strings.HasSuffix(typeString, "]") {
+		inner := parseArgTypeString(strings.TrimSuffix(strings.TrimPrefix(typeString, "["), "]"))
+		return createListType(inner)
+	}
+	// Scalars and JSON passthrough types such as "Object" are not namespaced object references
+	if objectTypeReference(typeString) == "" {
+		return createScalarType(typeString)
+	}
+	return GraphQLTypeDefinition{Kind: "object", Name: typeString}
+}
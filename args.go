@@ -0,0 +1,325 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GetArg safely extracts an argument as T, applying the same coercions as the GetXArg helpers
+// (float64→int, "true"→bool, []interface{}→[]string, ...). It falls back to the default,
+// or T's zero value, when the argument is missing or can't be converted.
+func GetArg[T any](args map[string]interface{}, name string, defaultValue ...T) T {
+	if val, exists := args[name]; exists && val != nil {
+		if typed, ok := coerceArg[T](val); ok {
+			return typed
+		}
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	var zero T
+	return zero
+}
+
+// coerceArg converts a raw argument value to T
+func coerceArg[T any](val interface{}) (T, bool) {
+	var zero T
+	if typed, ok := val.(T); ok {
+		return typed, true
+	}
+
+	var converted interface{}
+	var ok bool
+	switch any(zero).(type) {
+	case string:
+		converted, ok = fmt.Sprintf("%v", val), true
+	case int:
+		converted, ok = coerceInt(val)
+	case int64:
+		var i int
+		i, ok = coerceInt(val)
+		converted = int64(i)
+	case float64:
+		converted, ok = coerceFloat(val)
+	case bool:
+		converted, ok = coerceBool(val)
+	case []string:
+		converted, ok = coerceSlice(val, func(item interface{}) (string, bool) {
+			return fmt.Sprintf("%v", item), true
+		})
+	case []int:
+		converted, ok = coerceSlice(val, coerceInt)
+	case []float64:
+		converted, ok = coerceSlice(val, coerceFloat)
+	case []bool:
+		converted, ok = coerceSlice(val, coerceBoolItem)
+	case []map[string]interface{}:
+		converted, ok = coerceSlice(val, func(item interface{}) (map[string]interface{}, bool) {
+			obj, isMap := item.(map[string]interface{})
+			return obj, isMap
+		})
+	}
+	if !ok {
+		return zero, false
+	}
+	return converted.(T), true
+}
+
+// coerceInt converts numbers and numeric strings to int
+func coerceInt(val interface{}) (int, bool) {
+	switch v := val.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// coerceFloat converts numbers and numeric strings to float64
+func coerceFloat(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// coerceBool converts booleans and their string representations to bool
+func coerceBool(val interface{}) (bool, bool) {
+	switch v := val.(type) {
+	case bool:
+		return v, true
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, true
+		}
+	}
+	return false, false
+}
+
+// coerceBoolItem is coerceBool for list elements, which also accept numbers (non-zero is true)
+func coerceBoolItem(val interface{}) (bool, bool) {
+	switch v := val.(type) {
+	case int:
+		return v != 0, true
+	case float64:
+		return v != 0, true
+	}
+	return coerceBool(val)
+}
+
+// coerceSlice converts a []interface{} element-wise, dropping elements that don't convert
+func coerceSlice[E any](val interface{}, convert func(interface{}) (E, bool)) ([]E, bool) {
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	result := make([]E, 0, len(items))
+	for _, item := range items {
+		if converted, ok := convert(item); ok {
+			result = append(result, converted)
+		}
+	}
+	return result, true
+}
+
+// BindArgs decodes raw arguments into the struct pointed to by dest, matching fields by their json tags.
+// Values are coerced the same way the GetXArg helpers coerce them, so a float64 argument binds to an
+// int field and "true" binds to a bool field. Mismatches return a BadRequestError naming the field.
+func BindArgs(args map[string]interface{}, dest interface{}) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("BindArgs requires a non-nil pointer, got %T", dest)
+	}
+	if err := bindValue("", args, target.Elem()); err != nil {
+		return BadRequestError("Invalid arguments", err.Error())
+	}
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// bindValue assigns val to dst, coercing it to dst's type; path names the field for error messages
+func bindValue(path string, val interface{}, dst reflect.Value) error {
+	if val == nil {
+		return nil
+	}
+
+	// Types with their own JSON decoding (time.Time, custom enums, ...) decode themselves
+	if dst.Kind() != reflect.Ptr && dst.Kind() != reflect.Interface && reflect.PtrTo(dst.Type()).Implements(jsonUnmarshalerType) {
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return bindError(path, val, dst.Type())
+		}
+		if err := dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(raw); err != nil {
+			return fmt.Errorf("field '%s': %v", bindPath(path), err)
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		source := reflect.ValueOf(val)
+		if !source.Type().AssignableTo(dst.Type()) {
+			return bindError(path, val, dst.Type())
+		}
+		dst.Set(source)
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := bindValue(path, val, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+	case reflect.Struct:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return bindError(path, val, dst.Type())
+		}
+		return bindStruct(path, obj, dst)
+	case reflect.Map:
+		obj, ok := val.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return bindError(path, val, dst.Type())
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), len(obj))
+		for key, item := range obj {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := bindValue(joinBindPath(path, key), item, elem); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(result)
+	case reflect.Slice:
+		items := reflect.ValueOf(val)
+		if items.Kind() != reflect.Slice {
+			return bindError(path, val, dst.Type())
+		}
+		result := reflect.MakeSlice(dst.Type(), items.Len(), items.Len())
+		for i := 0; i < items.Len(); i++ {
+			if err := bindValue(fmt.Sprintf("%s[%d]", path, i), items.Index(i).Interface(), result.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(result)
+	case reflect.String:
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			return bindError(path, val, dst.Type())
+		}
+		dst.SetString(fmt.Sprintf("%v", val))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := coerceInt(val)
+		if !ok || dst.OverflowInt(int64(i)) {
+			return bindError(path, val, dst.Type())
+		}
+		dst.SetInt(int64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := coerceInt(val)
+		if !ok || i < 0 || dst.OverflowUint(uint64(i)) {
+			return bindError(path, val, dst.Type())
+		}
+		dst.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, ok := coerceFloat(val)
+		if !ok || dst.OverflowFloat(f) {
+			return bindError(path, val, dst.Type())
+		}
+		dst.SetFloat(f)
+	case reflect.Bool:
+		b, ok := coerceBool(val)
+		if !ok {
+			return bindError(path, val, dst.Type())
+		}
+		dst.SetBool(b)
+	default:
+		return bindError(path, val, dst.Type())
+	}
+	return nil
+}
+
+// bindStruct binds an argument object into a struct, following encoding/json's field naming rules
+func bindStruct(path string, obj map[string]interface{}, dst reflect.Value) error {
+	structType := dst.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		// Untagged embedded structs contribute their fields to the parent object
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := bindStruct(path, obj, dst.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		val, exists := obj[name]
+		if !exists {
+			// encoding/json matches keys case-insensitively, so BindArgs does too
+			for key, candidate := range obj {
+				if strings.EqualFold(key, name) {
+					val, exists = candidate, true
+					break
+				}
+			}
+		}
+		if !exists {
+			continue
+		}
+		if err := bindValue(joinBindPath(path, name), val, dst.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindError describes an argument that can't be converted to the destination field's type
+func bindError(path string, val interface{}, target reflect.Type) error {
+	return fmt.Errorf("field '%s': cannot convert %T to %s", bindPath(path), val, target)
+}
+
+// bindPath names the root value when the failing value is the whole argument set
+func bindPath(path string) string {
+	if path == "" {
+		return "args"
+	}
+	return path
+}
+
+// joinBindPath appends a field name to a dotted argument path
+func joinBindPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+// AuditRecord describes one state-changing call: a GraphQL mutation or a non-GET REST request
+type AuditRecord struct {
+	Timestamp    time.Time
+	RequestID    string
+	FunctionType string
+	FunctionName string
+	// Method is the HTTP method for REST requests
+	Method   string
+	UserID   string
+	TenantID string
+	// Args are the caller's input arguments, without host context data and with redacted fields masked
+	Args map[string]interface{}
+	// Before is the state the handler attached with SetAuditBefore, e.g. the record as it was
+	// before an update; nil when none was attached
+	Before interface{}
+	// Result is the handler's result, the after state of the change; nil when the call failed.
+	// Binary results and file downloads are described by their metadata only.
+	Result   interface{}
+	Success  bool
+	Error    string
+	Duration time.Duration
+}
+
+// auditBeforeKey is the request value under which SetAuditBefore stores the before state
+const auditBeforeKey = "sdk.audit_before"
+
+// SetAuditBefore attaches the state a mutation is about to change, e.g. the record loaded
+// before updating it, to the request's AuditRecord as Before. Call it from the resolver or
+// handler with the context Execute passed in; redacted fields are masked like the arguments.
+func SetAuditBefore(ctx context.Context, before interface{}) {
+	SetRequestValue(ctx, auditBeforeKey, before)
+}
+
+// AuditLogger receives an AuditRecord for every mutation and write REST request
+type AuditLogger interface {
+	LogAudit(ctx context.Context, record AuditRecord)
+}
+
+// AuditLoggerFunc adapts a plain function to the AuditLogger interface
+type AuditLoggerFunc func(ctx context.Context, record AuditRecord)
+
+// LogAudit calls f(ctx, record)
+func (f AuditLoggerFunc) LogAudit(ctx context.Context, record AuditRecord) {
+	f(ctx, record)
+}
+
+// LogAuditLogger returns an AuditLogger that writes records to the standard logger
+func LogAuditLogger() AuditLogger {
+	return AuditLoggerFunc(func(ctx context.Context, record AuditRecord) {
+		outcome := "ok"
+		if !record.Success {
+			outcome = "error: " + record.Error
+		}
+		log.Printf("Plugin SDK: Audit %s %s user=%s tenant=%s request=%s args=%v before=%v result=%v %s",
+			record.FunctionType, record.FunctionName, record.UserID, record.TenantID, record.RequestID, record.Args, record.Before, record.Result, outcome)
+	})
+}
+
+// SetAuditLogger records every GraphQL mutation and non-GET REST request with logger.
+// Argument fields named in redactFields or SetRedactedKeys (matched case-insensitively, at
+// any depth) are replaced with "***" before the record is built. Pass a nil logger to disable auditing.
+func (p *Plugin) SetAuditLogger(logger AuditLogger, redactFields ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.auditLogger = logger
+	p.auditRedactions = newRedactionSet(redactFields)
+}
+
+// audit builds and emits the AuditRecord for a state-changing request
+func (p *Plugin) audit(ctx context.Context, req *protobuff.ExecuteRequest, args map[string]interface{}, result interface{}, duration time.Duration, err error) {
+	p.mu.RLock()
+	logger, redactions := p.auditLogger, mergeRedactionSets(p.auditRedactions, p.redactionSet())
+	p.mu.RUnlock()
+
+	if logger == nil || !isWriteRequest(req) {
+		return
+	}
+
+	// Host context data (tokens, session details) is never part of the audited input
+	input := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if !strings.HasPrefix(key, "context_") {
+			input[key] = value
+		}
+	}
+
+	record := AuditRecord{
+		Timestamp:    time.Now(),
+		RequestID:    GetRequestID(ctx),
+		FunctionType: req.FunctionType,
+		FunctionName: req.FunctionName,
+		UserID:       GetUserID(args),
+		TenantID:     GetTenantID(args),
+		Args:         redactArgs(input, redactions),
+		Success:      err == nil,
+		Duration:     duration,
+	}
+	if req.FunctionType == "rest_api" {
+		record.Method = restRequestMethod(req.FunctionName)
+	}
+	if before, exists := GetRequestValue(ctx, auditBeforeKey); exists {
+		record.Before = redactValue(normalizeMaskInput(before), redactions)
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.Result = auditResult(result, redactions)
+	}
+
+	logger.LogAudit(ctx, record)
+}
+
+// auditResult converts a handler result to its redacted JSON form for an AuditRecord
+func auditResult(result interface{}, redactions map[string]bool) interface{} {
+	if binary, ok := asBinaryResult(result); ok {
+		return map[string]interface{}{"contentType": binary.ContentType, "size": len(binary.Data)}
+	}
+	if download, ok := asFileDownloadResult(result); ok {
+		return map[string]interface{}{"filename": download.Filename, "contentType": download.ContentType}
+	}
+	if partial, ok := asPartialResult(result); ok {
+		result = partial.Data
+	}
+	return redactValue(normalizeMaskInput(result), redactions)
+}
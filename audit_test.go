@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAuditRecordOmitsRedactedFields(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterMutation("createUser", FieldWithArgs("String", "Create a user", map[string]interface{}{
+		"name":     StringArg("Name"),
+		"ssn":      StringArg("Social security number"),
+		"password": StringArg("Password"),
+		"profile": ObjectArg("Profile", map[string]interface{}{
+			"ssn":  StringProperty("Nested social security number"),
+			"city": StringProperty("City"),
+		}),
+	}), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return "created", nil
+	})
+
+	var records []AuditRecord
+	p.SetAuditLogger(AuditLoggerFunc(func(ctx context.Context, record AuditRecord) {
+		records = append(records, record)
+	}), "ssn")
+
+	args, err := structpb.NewStruct(map[string]interface{}{
+		"name":     "Ada",
+		"ssn":      "123-45-6789",
+		"password": "hunter2",
+		"profile":  map[string]interface{}{"ssn": "987-65-4321", "city": "London"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{
+		FunctionType: "graphql_mutation",
+		FunctionName: "createUser",
+		Args:         args,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Execute() = %v, %v", resp, err)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("got %d audit records, want 1", len(records))
+	}
+	dump := fmt.Sprintf("%+v", records[0])
+	for _, secret := range []string{"123-45-6789", "987-65-4321", "hunter2"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("audit record contains redacted value %q: %s", secret, dump)
+		}
+	}
+	if records[0].Args["name"] != "Ada" {
+		t.Errorf("audit record dropped an unredacted field: %v", records[0].Args)
+	}
+	if records[0].Args["ssn"] != redactedValue {
+		t.Errorf("ssn = %v, want %q", records[0].Args["ssn"], redactedValue)
+	}
+}
+
+func TestAuditRecordCarriesBeforeAndAfterState(t *testing.T) {
+	type account struct {
+		ID       string `json:"id"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterMutation("updateEmail", FieldWithArgs("String", "Update an email", map[string]interface{}{
+		"email": StringArg("New email"),
+	}), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		SetAuditBefore(ctx, account{ID: "1", Email: "old@example.com", Password: "hunter2"})
+		return account{ID: "1", Email: args["email"].(string), Password: "hunter2"}, nil
+	})
+	p.RegisterMutation("failing", Field("String", "Always fails"), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return "ignored", InternalServerError("boom")
+	})
+
+	var records []AuditRecord
+	p.SetAuditLogger(AuditLoggerFunc(func(ctx context.Context, record AuditRecord) {
+		records = append(records, record)
+	}))
+
+	args, err := structpb.NewStruct(map[string]interface{}{"email": "new@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"updateEmail", "failing"} {
+		if _, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "graphql_mutation", FunctionName: name, Args: args}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d audit records, want 2", len(records))
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"before", records[0].Before, map[string]interface{}{"id": "1", "email": "old@example.com", "password": redactedValue}},
+		{"after", records[0].Result, map[string]interface{}{"id": "1", "email": "new@example.com", "password": redactedValue}},
+		{"failed call has no before", records[1].Before, nil},
+		{"failed call has no result", records[1].Result, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
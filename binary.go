@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"encoding/base64"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// BinaryResult is returned from a function or REST handler to send raw bytes (PDFs, images, ...)
+// instead of JSON. Execute packs it into the response result as:
+//
+//	{
+//	  "serialization": "binary",
+//	  "content_type":  "<ContentType>",
+//	  "data":          "<base64 of Data>",
+//	  "size":          <len(Data)>,
+//	  "function_name": "...",
+//	  "function_type": "..."
+//	}
+//
+// The host decodes "data" and serves it as-is with the given content type.
+type BinaryResult struct {
+	ContentType string
+	Data        []byte
+}
+
+// asBinaryResult reports whether a handler result is a BinaryResult (or a non-nil pointer to one)
+func asBinaryResult(result interface{}) (BinaryResult, bool) {
+	switch r := result.(type) {
+	case BinaryResult:
+		return r, true
+	case *BinaryResult:
+		if r != nil {
+			return *r, true
+		}
+	}
+	return BinaryResult{}, false
+}
+
+// binaryResultStruct builds the binary response envelope described on BinaryResult
+func binaryResultStruct(binary BinaryResult, functionName, functionType string) *structpb.Struct {
+	contentType := binary.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"serialization": structpb.NewStringValue("binary"),
+			"content_type":  structpb.NewStringValue(contentType),
+			"data":          structpb.NewStringValue(base64.StdEncoding.EncodeToString(binary.Data)),
+			"size":          structpb.NewNumberValue(float64(len(binary.Data))),
+			"function_name": structpb.NewStringValue(functionName),
+			"function_type": structpb.NewStringValue(functionType),
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestBinaryResultRoundTripsPNG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 60), B: 0xff, A: 0xff})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatal(err)
+	}
+	pngBytes := encoded.Bytes()
+
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterFunction("thumbnail", FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return BinaryResult{ContentType: "image/png", Data: pngBytes}, nil
+	}))
+
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "function", FunctionName: "thumbnail"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Execute() = %v, %v", resp, err)
+	}
+
+	envelope := &structpb.Struct{}
+	if err := resp.Result.UnmarshalTo(envelope); err != nil {
+		t.Fatal(err)
+	}
+	fields := envelope.AsMap()
+	if fields["serialization"] != "binary" || fields["content_type"] != "image/png" || fields["size"] != float64(len(pngBytes)) {
+		t.Errorf("envelope = %v", fields)
+	}
+
+	decoded, err := DecodeExecuteResult(resp.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary, ok := decoded.(BinaryResult)
+	if !ok {
+		t.Fatalf("decoded result = %T, want BinaryResult", decoded)
+	}
+	if binary.ContentType != "image/png" || !bytes.Equal(binary.Data, pngBytes) {
+		t.Fatalf("decoded %s with %d bytes, want image/png with %d bytes", binary.ContentType, len(binary.Data), len(pngBytes))
+	}
+	if _, err := png.Decode(bytes.NewReader(binary.Data)); err != nil {
+		t.Errorf("round-tripped bytes are not a valid PNG: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores serialized resolver results. Implement it on top of Redis or similar to share
+// cached results across plugin instances.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheKeyFunc builds the cache key for a resolver call; returning "" skips the cache.
+// args include the host context data (GetTenantID, GetUserID), so keys can be scoped per caller.
+type CacheKeyFunc func(name string, args map[string]interface{}) string
+
+// DefaultCacheKey scopes the key by resolver name, tenant and user, followed by the request
+// arguments. Other context values (request IDs, headers) are left out so they don't defeat the cache.
+func DefaultCacheKey(name string, args map[string]interface{}) string {
+	keyArgs := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if !strings.HasPrefix(key, "context_") {
+			keyArgs[key] = value
+		}
+	}
+	raw, err := json.Marshal(keyArgs)
+	if err != nil {
+		return ""
+	}
+	return name + ":" + GetTenantID(args) + ":" + GetUserID(args) + ":" + string(raw)
+}
+
+// CacheResolver wraps a read resolver so its results are served from cache for ttl.
+// Results are stored as JSON, so cache hits return the generic JSON types (maps, []interface{},
+// float64). keyFn defaults to DefaultCacheKey; errors are never cached.
+// Use RESTHandlerFunc(...) or FunctionHandlerFunc(...) to apply it to other handler types.
+func CacheResolver(cache Cache, keyFn CacheKeyFunc, ttl time.Duration, next ResolverFunc) ResolverFunc {
+	if keyFn == nil {
+		keyFn = DefaultCacheKey
+	}
+
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		info, _ := GetRequestInfo(ctx)
+		key := keyFn(info.FunctionName, args)
+		if key == "" {
+			return next(ctx, args)
+		}
+
+		if cached, exists := cache.Get(key); exists {
+			var result interface{}
+			if err := json.Unmarshal(cached, &result); err == nil {
+				return result, nil
+			}
+		}
+
+		result, err := next(ctx, args)
+		if err != nil {
+			return result, err
+		}
+		if raw, marshalErr := json.Marshal(result); marshalErr == nil {
+			cache.Set(key, raw, ttl)
+		}
+		return result, nil
+	}
+}
+
+// LRUCache is an in-process Cache that evicts the least recently used entry when full
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+}
+
+// lruEntry is a cached value and its expiry
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates an in-process cache holding at most capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key if it hasn't expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set caches value under key for ttl, evicting the least recently used entry when full
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
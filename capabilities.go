@@ -0,0 +1,69 @@
+package sdk
+
+// ProtocolVersion is the plugin protocol version negotiated in the go-plugin handshake
+const ProtocolVersion = 1
+
+// Features a host can probe for before relying on them
+const (
+	FeatureJSONBytesSerialization = "json_bytes_serialization"
+	FeatureBinaryResponses        = "binary_responses"
+	FeatureResponseEncoders       = "response_encoders"
+	FeatureRESTEnvelope           = "rest_envelope"
+	FeatureDryRun                 = "dry_run"
+	FeatureDebugInfo              = "debug_info"
+	FeatureIntrospection          = "introspection"
+	FeaturePing                   = "ping"
+	// FeatureBufferedSSE means text/event-stream responses are buffered and delivered in one
+	// body when the handler returns, not streamed live; see BufferedSSEHandler
+	FeatureBufferedSSE = "buffered_sse"
+)
+
+// Capabilities describes what this SDK build supports, so the host can avoid features an older
+// plugin doesn't implement. Hosts read it from the built-in "__capabilities" function.
+type Capabilities struct {
+	SDKVersion      string   `json:"sdk_version"`
+	ProtocolVersion int      `json:"protocol_version"`
+	Features        []string `json:"features"`
+}
+
+// Capabilities reports the SDK version and the features supported by this build
+func (p *Plugin) Capabilities() Capabilities {
+	return Capabilities{
+		SDKVersion:      Version,
+		ProtocolVersion: ProtocolVersion,
+		Features: []string{
+			FeatureJSONBytesSerialization,
+			FeatureBinaryResponses,
+			FeatureResponseEncoders,
+			FeatureRESTEnvelope,
+			FeatureDryRun,
+			FeatureDebugInfo,
+			FeatureIntrospection,
+			FeaturePing,
+			FeatureBufferedSSE,
+		},
+	}
+}
+
+// HasFeature reports whether this SDK build supports the named feature
+func (c Capabilities) HasFeature(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// toMap converts the capabilities to a protobuf-compatible map
+func (c Capabilities) toMap() map[string]interface{} {
+	features := make([]interface{}, len(c.Features))
+	for i, feature := range c.Features {
+		features[i] = feature
+	}
+	return map[string]interface{}{
+		"sdk_version":      c.SDKVersion,
+		"protocol_version": c.ProtocolVersion,
+		"features":         features,
+	}
+}
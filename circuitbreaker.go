@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// CBOptions configures a circuit breaker
+type CBOptions struct {
+	FailureThreshold int           // Consecutive failures that open the breaker (default 5)
+	Cooldown         time.Duration // How long the breaker stays open before probing again (default 30s)
+	// IsFailure reports whether an error counts against the dependency (default
+	// IsCircuitFailure). Other errors, such as bad input, count as successful calls.
+	IsFailure func(err error) bool
+}
+
+// IsCircuitFailure is the default CBOptions.IsFailure: 5xx errors (including plain errors
+// without a status code) and retryable errors count as failures, while 4xx client errors
+// such as bad input or missing records don't
+func IsCircuitFailure(err error) bool {
+	return err != nil && (GetErrorCode(err) >= 500 || IsRetryable(err))
+}
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures for one breaker name
+type circuitBreaker struct {
+	mu            sync.Mutex
+	opts          CBOptions
+	state         circuitState
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// circuitBreakerRegistry holds the breaker state per name, shared by every resolver wrapped
+// with that name. Each plugin has its own; the zero value is ready to use.
+type circuitBreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// standaloneCircuitBreakers is used by CircuitBreaker when no plugin has been initialized
+var standaloneCircuitBreakers circuitBreakerRegistry
+
+// get returns the breaker registered under name, creating it on first use. A breaker keeps
+// the options it was created with; conflicting options for the same name are logged.
+func (r *circuitBreakerRegistry) get(name string, opts CBOptions) *circuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = IsCircuitFailure
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breaker, exists := r.breakers[name]
+	if !exists {
+		if r.breakers == nil {
+			r.breakers = make(map[string]*circuitBreaker)
+		}
+		breaker = &circuitBreaker{opts: opts}
+		r.breakers[name] = breaker
+		return breaker
+	}
+
+	existing := breaker.opts
+	if existing.FailureThreshold != opts.FailureThreshold || existing.Cooldown != opts.Cooldown ||
+		reflect.ValueOf(existing.IsFailure).Pointer() != reflect.ValueOf(opts.IsFailure).Pointer() {
+		log.Printf("SDK Warning: circuit breaker '%s' is already configured with different options; keeping the first (threshold %d, cooldown %v)",
+			name, existing.FailureThreshold, existing.Cooldown)
+	}
+	return breaker
+}
+
+// allow reports whether a call may proceed, letting a single probe through once the cooldown ends
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.opts.Cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probeInFlight = true
+		return true
+	case circuitHalfOpen:
+		if b.probeInFlight {
+			return false
+		}
+		b.probeInFlight = true
+		return true
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a call
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probeInFlight = false
+	if !failed {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.opts.FailureThreshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// CircuitBreaker wraps a resolver so it fails fast with a 503 CodedError after
+// opts.FailureThreshold consecutive failures (see CBOptions.IsFailure for what counts). The
+// breaker stays open for opts.Cooldown, then lets one probe call through: success closes it,
+// failure or a panic opens it again. State is shared by every resolver of the plugin wrapped
+// with the same name, so one breaker can guard a whole dependency; the options of the first
+// wrap apply. Use RESTHandlerFunc(...) or FunctionHandlerFunc(...) to apply it to other handler types.
+func (p *Plugin) CircuitBreaker(name string, opts CBOptions, next ResolverFunc) ResolverFunc {
+	return wrapCircuitBreaker(p.circuitBreakers.get(name, opts), name, next)
+}
+
+// CircuitBreaker wraps a resolver with a circuit breaker of the current plugin,
+// see Plugin.CircuitBreaker
+func CircuitBreaker(name string, opts CBOptions, next ResolverFunc) ResolverFunc {
+	if currentPlugin != nil {
+		return currentPlugin.CircuitBreaker(name, opts, next)
+	}
+	return wrapCircuitBreaker(standaloneCircuitBreakers.get(name, opts), name, next)
+}
+
+// wrapCircuitBreaker guards next with breaker
+func wrapCircuitBreaker(breaker *circuitBreaker, name string, next ResolverFunc) ResolverFunc {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		if !breaker.allow() {
+			return nil, ServiceUnavailableError("Service unavailable", fmt.Sprintf("circuit breaker '%s' is open", name))
+		}
+
+		// A panic counts as a failure, so a panicking probe can't leave the breaker half-open
+		failed := true
+		defer func() {
+			breaker.record(failed)
+		}()
+
+		result, err := next(ctx, args)
+		failed = breaker.opts.IsFailure(err)
+		return result, err
+	}
+}
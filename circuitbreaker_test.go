@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerCountsOnlyDependencyFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		opts     CBOptions
+		wantOpen bool
+	}{
+		{"bad request", BadRequestError("invalid input"), CBOptions{FailureThreshold: 2}, false},
+		{"not found", NotFoundError("missing"), CBOptions{FailureThreshold: 2}, false},
+		{"unauthorized", UnauthorizedError("no token"), CBOptions{FailureThreshold: 2}, false},
+		{"internal error", InternalServerError("boom"), CBOptions{FailureThreshold: 2}, true},
+		{"plain error", errors.New("connection refused"), CBOptions{FailureThreshold: 2}, true},
+		{"custom classifier", BadRequestError("invalid input"), CBOptions{
+			FailureThreshold: 2,
+			IsFailure:        func(err error) bool { return err != nil },
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Init("test-plugin", "1.0.0", "")
+			calls := 0
+			guarded := p.CircuitBreaker("dependency", tt.opts, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				calls++
+				return nil, tt.err
+			})
+
+			for i := 0; i < 3; i++ {
+				guarded(context.Background(), nil)
+			}
+			if open := calls < 3; open != tt.wantOpen {
+				t.Errorf("breaker open = %v after 3 calls returning %v, want %v", open, tt.err, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerRecoversFromPanickingProbe(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	shouldPanic := false
+	guarded := p.CircuitBreaker("dependency", CBOptions{FailureThreshold: 1, Cooldown: time.Millisecond}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		if shouldPanic {
+			panic("probe crashed")
+		}
+		return nil, errors.New("down")
+	})
+
+	guarded(context.Background(), nil)
+	time.Sleep(2 * time.Millisecond)
+
+	shouldPanic = true
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("probe did not panic")
+			}
+		}()
+		guarded(context.Background(), nil)
+	}()
+
+	// The panic reopened the breaker; after the next cooldown another probe must be let through
+	time.Sleep(2 * time.Millisecond)
+	shouldPanic = false
+	if _, err := guarded(context.Background(), nil); err == nil || GetErrorCode(err) == 503 {
+		t.Errorf("probe after a panicking probe = %v, want the dependency's error", err)
+	}
+}
+
+func TestCircuitBreakerStateIsPerPlugin(t *testing.T) {
+	failing := func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("down")
+	}
+	opts := CBOptions{FailureThreshold: 1, Cooldown: time.Minute}
+
+	first := Init("first-plugin", "1.0.0", "")
+	first.CircuitBreaker("dependency", opts, failing)(context.Background(), nil)
+	if _, err := first.CircuitBreaker("dependency", opts, failing)(context.Background(), nil); GetErrorCode(err) != 503 {
+		t.Fatalf("first plugin breaker = %v, want open", err)
+	}
+
+	second := Init("second-plugin", "1.0.0", "")
+	if _, err := second.CircuitBreaker("dependency", opts, failing)(context.Background(), nil); GetErrorCode(err) == 503 {
+		t.Errorf("second plugin shares the first plugin's open breaker")
+	}
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CORSConfig configures the CORS headers attached to REST responses
+type CORSConfig struct {
+	AllowedOrigins []string // Origins allowed to call the plugin; "*" allows any origin
+	AllowedMethods []string // Methods advertised on preflight; defaults to the common REST methods
+	AllowedHeaders []string // Request headers advertised on preflight; defaults to echoing the requested ones
+	MaxAge         int      // Seconds a preflight result may be cached; 0 omits the header
+}
+
+// defaultCORSMethods are advertised on preflight when CORSConfig.AllowedMethods is empty
+var defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// SetCORS enables CORS for REST endpoints. Responses to allowed origins carry access-control-*
+// headers in the result's "headers" map, and OPTIONS preflight requests are answered automatically.
+func (p *Plugin) SetCORS(config CORSConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cors = &config
+}
+
+// corsHeaders returns the CORS response headers for the request's Origin, or nil when CORS is
+// disabled, the request has no Origin or the Origin isn't allowed
+func (p *Plugin) corsHeaders(args map[string]interface{}, preflight bool) map[string]interface{} {
+	p.mu.RLock()
+	config := p.cors
+	p.mu.RUnlock()
+
+	origin := GetContextString(args, "origin", GetContextString(args, "http_origin"))
+	if config == nil || origin == "" {
+		return nil
+	}
+
+	allowOrigin := ""
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == "*" {
+			allowOrigin = "*"
+		} else if strings.EqualFold(allowed, origin) {
+			allowOrigin = origin
+			break
+		}
+	}
+	if allowOrigin == "" {
+		return nil
+	}
+
+	headers := map[string]interface{}{
+		"access-control-allow-origin": allowOrigin,
+	}
+	if allowOrigin != "*" {
+		headers["vary"] = "Origin"
+	}
+	if !preflight {
+		return headers
+	}
+
+	methods := config.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers["access-control-allow-methods"] = strings.Join(methods, ", ")
+
+	if len(config.AllowedHeaders) > 0 {
+		headers["access-control-allow-headers"] = strings.Join(config.AllowedHeaders, ", ")
+	} else if requested := GetContextString(args, "access_control_request_headers"); requested != "" {
+		headers["access-control-allow-headers"] = requested
+	}
+	if config.MaxAge > 0 {
+		headers["access-control-max-age"] = strconv.Itoa(config.MaxAge)
+	}
+	return headers
+}
+
+// corsPreflightResponse answers an OPTIONS request that has no registered handler when CORS is
+// enabled, rejecting origins outside the allowlist. It reports false when CORS doesn't apply.
+func (impl *pluginImpl) corsPreflightResponse(ctx context.Context, req *protobuff.ExecuteRequest, args map[string]interface{}) (*protobuff.ExecuteResponse, bool) {
+	impl.plugin.mu.RLock()
+	enabled := impl.plugin.cors != nil
+	impl.plugin.mu.RUnlock()
+
+	if !enabled || restRequestMethod(req.FunctionName) != "OPTIONS" {
+		return nil, false
+	}
+
+	headers := impl.plugin.corsHeaders(args, true)
+	if headers == nil {
+		origin := GetContextString(args, "origin", GetContextString(args, "http_origin"))
+		return impl.errorResponse(ctx, req, ForbiddenError("CORS origin not allowed", origin), nil), true
+	}
+
+	headersValue, err := structpb.NewValue(headers)
+	if err != nil {
+		return impl.errorResponse(ctx, req, InternalServerError("Failed to build CORS headers", err.Error()), nil), true
+	}
+	anyResult, err := anypb.New(&structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"data":          structpb.NewNullValue(),
+			"status":        structpb.NewNumberValue(204),
+			"headers":       headersValue,
+			"function_name": structpb.NewStringValue(req.FunctionName),
+			"function_type": structpb.NewStringValue(req.FunctionType),
+		},
+	})
+	if err != nil {
+		return impl.errorResponse(ctx, req, InternalServerError("Failed to create any result", err.Error()), nil), true
+	}
+
+	return &protobuff.ExecuteResponse{
+		Success: true,
+		Message: "CORS preflight",
+		Result:  anyResult,
+	}, true
+}
+
+// restRequestMethod extracts the HTTP method from a REST function name in either the
+// "rest_get_users" or the "GET_/users" format
+func restRequestMethod(functionName string) string {
+	if strings.HasPrefix(functionName, "rest_") {
+		parts := strings.SplitN(functionName, "_", 3)
+		if len(parts) < 3 {
+			return ""
+		}
+		return strings.ToUpper(parts[1])
+	}
+	return strings.ToUpper(strings.SplitN(functionName, "_", 2)[0])
+}
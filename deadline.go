@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// GetDeadline returns the request deadline the host passed as "deadline" context data, either
+// an RFC 3339 timestamp or a Unix time in milliseconds
+func GetDeadline(args map[string]interface{}) (time.Time, bool) {
+	switch v := args["context_deadline"].(type) {
+	case string:
+		if deadline, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return deadline, true
+		}
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.UnixMilli(ms), true
+		}
+	case float64:
+		return time.UnixMilli(int64(v)), true
+	case int64:
+		return time.UnixMilli(v), true
+	case int:
+		return time.UnixMilli(int64(v)), true
+	}
+	return time.Time{}, false
+}
+
+// SetExecutionTimeout bounds every handler invocation. When the host also sends a deadline,
+// the earlier of the two applies. Zero (the default) leaves only the host deadline.
+func (p *Plugin) SetExecutionTimeout(timeout time.Duration) {
+	p.executionTimeout.Store(int64(timeout))
+}
+
+// withExecutionDeadline derives the handler context from the host deadline and the execution
+// timeout, whichever is earlier. The returned cancel func must always be called.
+func (p *Plugin) withExecutionDeadline(ctx context.Context, args map[string]interface{}) (context.Context, context.CancelFunc) {
+	deadline, hasDeadline := GetDeadline(args)
+	if timeout := time.Duration(p.executionTimeout.Load()); timeout > 0 {
+		if timeoutDeadline := time.Now().Add(timeout); !hasDeadline || timeoutDeadline.Before(deadline) {
+			deadline, hasDeadline = timeoutDeadline, true
+		}
+	}
+	if !hasDeadline {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// deadlineError turns a bare context.DeadlineExceeded returned by a handler into a 504
+// CodedError, leaving every other error unchanged
+func deadlineError(err error) error {
+	if err == nil || IsCodedError(err) || IsGraphQLError(err) || !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	timeoutErr := GatewayTimeoutError("Request deadline exceeded", err.Error()).(*CodedError)
+	timeoutErr.Err = err
+	return timeoutErr
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+// SetDebugMode enables or disables the "__debug" section in Execute results, which echoes the
+// parsed arguments, resolved definition and execution time. Never enable it in production.
+func (p *Plugin) SetDebugMode(enabled bool) {
+	p.debugMode.Store(enabled)
+}
+
+// buildDebugInfo describes how a request was parsed and executed
+func (impl *pluginImpl) buildDebugInfo(req *protobuff.ExecuteRequest, args map[string]interface{}, duration time.Duration) map[string]interface{} {
+	// Context values are left out and redacted keys masked so secrets aren't echoed back
+	rawArgs := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if !strings.HasPrefix(key, "context_") {
+			rawArgs[key] = value
+		}
+	}
+	rawArgs = redactArgs(rawArgs, impl.plugin.redactionSet())
+
+	debugInfo := map[string]interface{}{
+		"function_name": req.FunctionName,
+		"function_type": req.FunctionType,
+		"duration_ms":   float64(duration.Microseconds()) / 1000.0,
+		"args":          toJSONCompatible(rawArgs),
+	}
+
+	switch req.FunctionType {
+	case "graphql_query", "graphql_mutation":
+		debugInfo["parsed_args"] = toJSONCompatible(ParseArgsForResolver(req.FunctionName, rawArgs))
+		if field, exists := impl.plugin.GetQueryField(req.FunctionName); exists {
+			debugInfo["field"] = impl.serializeGraphQLField(field)
+		} else if field, exists := impl.plugin.GetMutationField(req.FunctionName); exists {
+			debugInfo["field"] = impl.serializeGraphQLField(field)
+		}
+
+	case "rest_api":
+		parsed := ParseRESTArgs(rawArgs)
+		delete(parsed, "raw")
+		debugInfo["parsed_args"] = toJSONCompatible(parsed)
+		if _, handlerKey, exists := impl.plugin.lookupRESTHandler(req.FunctionName); exists {
+			debugInfo["handler"] = handlerKey
+		}
+	}
+
+	return debugInfo
+}
+
+// toJSONCompatible normalizes a value (e.g. typed slices from argument parsing) into the
+// generic JSON types that structpb accepts
+func toJSONCompatible(value interface{}) interface{} {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil
+	}
+	return normalized
+}
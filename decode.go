@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DecodeExecuteResult unwraps the result of an ExecuteResponse into the "data" the function
+// returned. It is the inverse of Execute's serialization and detects which one was used:
+// a structpb map, a JSON string flagged "serialization": "json_bytes", or the binary envelope
+// flagged "serialization": "binary" (returned as a BinaryResult).
+func DecodeExecuteResult(result *anypb.Any) (interface{}, error) {
+	if result == nil {
+		return nil, nil
+	}
+
+	message, err := result.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack result: %v", err)
+	}
+
+	var payload map[string]interface{}
+	switch m := message.(type) {
+	case *structpb.Struct:
+		payload = m.AsMap()
+	case *structpb.Value:
+		// serializeComplexData sends the whole result map as a JSON string wrapped in a Value
+		if err := json.Unmarshal([]byte(m.GetStringValue()), &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON result: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected result type %s", result.TypeUrl)
+	}
+
+	if payload["serialization"] == "binary" {
+		encoded, _ := payload["data"].(string)
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode binary result: %v", err)
+		}
+		contentType, _ := payload["content_type"].(string)
+		return BinaryResult{ContentType: contentType, Data: data}, nil
+	}
+
+	return payload["data"], nil
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDecodeExecuteResultRoundTrips(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    interface{}
+		want      interface{}
+		jsonBytes bool
+	}{
+		{"string", "ok", "ok", false},
+		{"map", map[string]interface{}{"id": "1", "count": 2.0}, map[string]interface{}{"id": "1", "count": 2.0}, false},
+		{
+			"slice of maps",
+			[]map[string]interface{}{{"id": "1"}, {"id": "2", "tags": []interface{}{"a"}}},
+			[]interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2", "tags": []interface{}{"a"}}},
+			true,
+		},
+		{"nil", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Init("test-plugin", "1.0.0", "")
+			p.RegisterFunction("fetch", FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				return tt.result, nil
+			}))
+
+			resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "function", FunctionName: "fetch"})
+			if err != nil || !resp.Success {
+				t.Fatalf("Execute() = %v, %v", resp, err)
+			}
+
+			if tt.jsonBytes && !resp.Result.MessageIs(&structpb.Value{}) {
+				t.Fatalf("result type = %s, want JSON bytes Value", resp.Result.TypeUrl)
+			}
+			if !tt.jsonBytes && !resp.Result.MessageIs(&structpb.Struct{}) {
+				t.Fatalf("result type = %s, want Struct", resp.Result.TypeUrl)
+			}
+
+			got, err := DecodeExecuteResult(resp.Result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeExecuteResult() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
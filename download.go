@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+// FileDownloadResult is returned from a REST handler or function to send a file as a binary
+// result with a Content-Disposition header. Reader is closed afterwards if it implements io.Closer.
+//
+// This is an in-memory fallback, not chunked streaming: Execute is a unary RPC and can't stream,
+// so the whole file is read into memory and sent base64-encoded in a single response. The
+// response is capped at the smaller of SetMaxResponseBytes and SetMaxGRPCMessageSize, or
+// DefaultMaxResponseBytes (4MB) when neither is set. After reserving room for the response
+// envelope and the 4/3 growth of base64, the largest file is about 3MB at the defaults; larger
+// files fail with a 413 PayloadTooLargeError. Serve bigger files from storage the client can
+// fetch directly (e.g. a signed URL) instead.
+type FileDownloadResult struct {
+	Filename string
+	// ContentType defaults to the type registered for the file extension, or application/octet-stream
+	ContentType string
+	Reader      io.Reader
+}
+
+// asFileDownloadResult reports whether a handler result is a FileDownloadResult
+func asFileDownloadResult(result interface{}) (FileDownloadResult, bool) {
+	switch r := result.(type) {
+	case FileDownloadResult:
+		return r, true
+	case *FileDownloadResult:
+		if r != nil {
+			return *r, true
+		}
+	}
+	return FileDownloadResult{}, false
+}
+
+// fileDownloadEnvelopeBytes is the room reserved for the rest of the response: the binary
+// envelope fields, headers such as Content-Disposition, and protobuf framing
+const fileDownloadEnvelopeBytes = 16 * 1024
+
+// fileDownloadLimit returns the largest file a FileDownloadResult may carry: the response
+// limit, less the envelope, divided by the 4/3 growth of base64 encoding
+func (p *Plugin) fileDownloadLimit() int64 {
+	var limit int64
+	for _, candidate := range []int64{p.maxResponseBytes.Load(), int64(p.MaxGRPCMessageSize())} {
+		if candidate > 0 && (limit == 0 || candidate < limit) {
+			limit = candidate
+		}
+	}
+	if limit == 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	if limit <= fileDownloadEnvelopeBytes {
+		return 0
+	}
+	// Base64 encodes every 3 bytes as 4, so this many bytes encode to at most limit-envelope
+	return (limit - fileDownloadEnvelopeBytes) / 4 * 3
+}
+
+// readFileDownload reads a FileDownloadResult, up to fileDownloadLimit, into a BinaryResult and
+// returns it with its Content-Disposition header value
+func (p *Plugin) readFileDownload(ctx context.Context, download FileDownloadResult) (BinaryResult, string, error) {
+	if closer, ok := download.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if download.Reader == nil {
+		return BinaryResult{}, "", InternalServerError("File download has no reader")
+	}
+	if err := ctx.Err(); err != nil {
+		return BinaryResult{}, "", err
+	}
+
+	limit := p.fileDownloadLimit()
+	tooLarge := PayloadTooLargeError("Response too large", fmt.Sprintf("file '%s' exceeds the %d byte download limit; Execute can't stream, so larger files must be served another way", download.Filename, limit))
+
+	// Reject files of known size before reading any of them
+	if size, known := readerSize(download.Reader); known && size > limit {
+		return BinaryResult{}, "", tooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(download.Reader, limit+1))
+	if err != nil {
+		return BinaryResult{}, "", InternalServerError("Failed to read file download", err.Error())
+	}
+	if int64(len(data)) > limit {
+		return BinaryResult{}, "", tooLarge
+	}
+
+	contentType := download.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(download.Filename))
+	}
+
+	disposition := "attachment"
+	if download.Filename != "" {
+		if formatted := mime.FormatMediaType("attachment", map[string]string{"filename": download.Filename}); formatted != "" {
+			disposition = formatted
+		}
+	}
+
+	return BinaryResult{ContentType: contentType, Data: data}, disposition, nil
+}
+
+// readerSize returns the remaining size of readers that report it, such as *os.File,
+// *bytes.Reader and *strings.Reader
+func readerSize(reader io.Reader) (int64, bool) {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		return info.Size(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+func TestFileDownloadLimitAccountsForEncoding(t *testing.T) {
+	defaultFileLimit := (DefaultMaxResponseBytes - fileDownloadEnvelopeBytes) / 4 * 3
+
+	tests := []struct {
+		name             string
+		maxResponseBytes int64
+		fileBytes        int64
+		wantDownloadErr  bool
+	}{
+		{"at the default limit", 0, defaultFileLimit, false},
+		{"one byte over the default limit", 0, defaultFileLimit + 1, true},
+		{"3.5MB at the default 4MB response limit", 0, 3584 << 10, true},
+		{"at a lowered limit", 1 << 20, (1<<20 - fileDownloadEnvelopeBytes) / 4 * 3, false},
+		{"over a lowered limit", 1 << 20, 1 << 20, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Init("test-plugin", "1.0.0", "")
+			if tt.maxResponseBytes > 0 {
+				p.SetMaxResponseBytes(tt.maxResponseBytes)
+			}
+			data := bytes.Repeat([]byte{0xff}, int(tt.fileBytes))
+			p.RegisterFunction("export", FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				return FileDownloadResult{Filename: "export.bin", Reader: bytes.NewReader(data)}, nil
+			}))
+
+			resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "function", FunctionName: "export"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantDownloadErr {
+				if !resp.Success {
+					t.Fatalf("Execute() = %s, want the %d byte file to fit", resp.Message, tt.fileBytes)
+				}
+				return
+			}
+			// Oversized files must be rejected by the download limit, not the generic response size check
+			if resp.Success || !strings.Contains(resp.Message, "download limit") {
+				t.Errorf("Execute() success = %v, message = %q; want the download limit error", resp.Success, resp.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DryRunFunctionPrefix marks an Execute call as validation-only, e.g. "__validate:createUser".
+// Hosts can alternatively set "dry_run": true in the request context.
+const DryRunFunctionPrefix = "__validate:"
+
+// ArgValidationError describes a single argument that failed validation
+type ArgValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidateArgs checks raw arguments against the field definition without running the resolver.
+// It reports missing required arguments and values whose shape doesn't match the declared type.
+func (p *ArgParser) ValidateArgs(rawArgs map[string]interface{}) []ArgValidationError {
+	var validationErrors []ArgValidationError
+
+	argNames := make([]string, 0, len(p.fieldDef.Args))
+	for argName := range p.fieldDef.Args {
+		argNames = append(argNames, argName)
+	}
+	sort.Strings(argNames)
+
+	for _, argName := range argNames {
+		argDefMap, ok := p.fieldDef.Args[argName].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		argType := argTypeString(argDefMap)
+
+		rawValue, exists := rawArgs[argName]
+		if !exists || rawValue == nil {
+			if _, hasDefault := argDefaultValue(argDefMap); strings.HasSuffix(argType, "!") && !hasDefault {
+				validationErrors = append(validationErrors, ArgValidationError{
+					Field:   argName,
+					Message: fmt.Sprintf("argument '%s' of type %s is required", argName, argType),
+				})
+			}
+			continue
+		}
+
+		if message := validateArgValue(rawValue, argType); message != "" {
+			validationErrors = append(validationErrors, ArgValidationError{Field: argName, Message: message})
+		} else if message := listConstraintViolation(rawValue, argDefMap); message != "" {
+			validationErrors = append(validationErrors, ArgValidationError{Field: argName, Message: message})
+		}
+	}
+
+	return validationErrors
+}
+
+// validateArgValue returns a description of why rawValue doesn't fit argType, or "" if it does
+func validateArgValue(rawValue interface{}, argType string) string {
+	baseType := strings.TrimSuffix(argType, "!")
+
+	if strings.HasPrefix(baseType, "[") && strings.HasSuffix(baseType, "]") {
+		items, ok := rawValue.([]interface{})
+		if !ok {
+			return fmt.Sprintf("expected a list for type %s, got %T", argType, rawValue)
+		}
+		itemType := strings.TrimSuffix(strings.TrimPrefix(baseType, "["), "]")
+		for i, item := range items {
+			if item == nil {
+				if strings.HasSuffix(itemType, "!") {
+					return fmt.Sprintf("item %d must not be null", i)
+				}
+				continue
+			}
+			if message := validateArgValue(item, itemType); message != "" {
+				return fmt.Sprintf("item %d: %s", i, message)
+			}
+		}
+		return ""
+	}
+
+	switch baseType {
+	case "String", "ID", "DateTime":
+		if _, ok := rawValue.(string); !ok {
+			return fmt.Sprintf("expected %s, got %T", baseType, rawValue)
+		}
+	case "Int":
+		switch v := rawValue.(type) {
+		case int, int64:
+		case float64:
+			if v != float64(int64(v)) {
+				return fmt.Sprintf("expected Int, got non-integer number %v", v)
+			}
+		case string:
+			if _, err := strconv.Atoi(v); err != nil {
+				return fmt.Sprintf("expected Int, got %q", v)
+			}
+		default:
+			return fmt.Sprintf("expected Int, got %T", rawValue)
+		}
+	case "Float":
+		switch v := rawValue.(type) {
+		case float64, float32, int, int64:
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return fmt.Sprintf("expected Float, got %q", v)
+			}
+		default:
+			return fmt.Sprintf("expected Float, got %T", rawValue)
+		}
+	case "Boolean":
+		if _, ok := rawValue.(bool); !ok {
+			return fmt.Sprintf("expected Boolean, got %T", rawValue)
+		}
+	case "Object":
+		if _, ok := rawValue.(map[string]interface{}); !ok {
+			return fmt.Sprintf("expected an object, got %T", rawValue)
+		}
+	default:
+		if _, _, ok := lookupRegisteredObjectType(baseType); ok {
+			if _, isMap := rawValue.(map[string]interface{}); !isMap {
+				return fmt.Sprintf("expected %s object, got %T", baseType, rawValue)
+			}
+		}
+	}
+
+	return ""
+}
+
+// dryRunTarget reports whether the request only asks for validation and which resolver it targets
+func dryRunTarget(req *protobuff.ExecuteRequest, args map[string]interface{}) (string, bool) {
+	if strings.HasPrefix(req.FunctionName, DryRunFunctionPrefix) {
+		return strings.TrimPrefix(req.FunctionName, DryRunFunctionPrefix), true
+	}
+	if dryRun, ok := args["context_dry_run"].(bool); ok && dryRun {
+		return req.FunctionName, true
+	}
+	return "", false
+}
+
+// dryRunResponse validates the arguments for a GraphQL resolver and reports the outcome
+// as {valid, errors} without invoking the resolver
+func (impl *pluginImpl) dryRunResponse(req *protobuff.ExecuteRequest, resolverName string, args map[string]interface{}) *protobuff.ExecuteResponse {
+	if req.FunctionType != "graphql_query" && req.FunctionType != "graphql_mutation" {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Dry run is not supported for function type: %s", req.FunctionType),
+		}
+	}
+
+	field, exists := impl.plugin.lookupField(resolverName)
+	if !exists {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Unknown GraphQL resolver: %s", resolverName),
+		}
+	}
+
+	parser := impl.plugin.newArgParser(field)
+	args = parser.transformArgs(args)
+	validationErrors := parser.ValidateArgs(args)
+	if impl.plugin.strictArgs.Load() {
+		for _, argName := range parser.unknownArgs(args) {
+			validationErrors = append(validationErrors, ArgValidationError{Field: argName, Message: "unknown argument"})
+		}
+	}
+	errorList := make([]interface{}, len(validationErrors))
+	for i, validationErr := range validationErrors {
+		errorList[i] = map[string]interface{}{
+			"field":   validationErr.Field,
+			"message": validationErr.Message,
+		}
+	}
+
+	resultStruct, err := structpb.NewStruct(map[string]interface{}{
+		"data": map[string]interface{}{
+			"valid":  len(validationErrors) == 0,
+			"errors": errorList,
+		},
+		"function_name": resolverName,
+		"function_type": req.FunctionType,
+		"dry_run":       true,
+	})
+	if err != nil {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to serialize validation result: %v", err),
+		}
+	}
+
+	anyResult, err := anypb.New(resultStruct)
+	if err != nil {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create validation result: %v", err),
+		}
+	}
+
+	return &protobuff.ExecuteResponse{
+		Success: true,
+		Message: "Validation completed",
+		Result:  anyResult,
+	}
+}
@@ -0,0 +1,159 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ResponseEncoderFunc encodes a REST handler result into the body for a content type
+type ResponseEncoderFunc func(result interface{}) ([]byte, error)
+
+// Built-in content types
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeCSV  = "text/csv"
+)
+
+// RegisterResponseEncoder registers an encoder used when a REST request's Accept header asks
+// for contentType. JSON is the default and is returned in the regular structured format.
+func (p *Plugin) RegisterResponseEncoder(contentType string, encoder ResponseEncoderFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.responseEncoders[strings.ToLower(contentType)] = encoder
+}
+
+// negotiateContentType picks the registered content type that best matches the request's
+// Accept header, returning an empty string when the default JSON response should be used
+func (p *Plugin) negotiateContentType(args map[string]interface{}) (string, ResponseEncoderFunc) {
+	accept := GetContextString(args, "accept", GetContextString(args, "http_accept"))
+	if accept == "" {
+		return "", nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, mediaType := range parseAccept(accept) {
+		if mediaType == ContentTypeJSON || mediaType == "*/*" {
+			return "", nil
+		}
+		if encoder, exists := p.responseEncoders[mediaType]; exists {
+			return mediaType, encoder
+		}
+	}
+	return "", nil
+}
+
+// parseAccept returns the media types of an Accept header ordered by quality
+func parseAccept(accept string) []string {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	mediaTypes := make([]string, len(ranges))
+	for i, r := range ranges {
+		mediaTypes[i] = r.mediaType
+	}
+	return mediaTypes
+}
+
+// JSONEncoder encodes a result as JSON
+func JSONEncoder(result interface{}) ([]byte, error) {
+	return json.Marshal(result)
+}
+
+// CSVEncoder encodes a list of objects ([]map[string]interface{}, []interface{} of maps,
+// or a slice of structs) as CSV with a header row of the sorted union of keys
+func CSVEncoder(result interface{}) ([]byte, error) {
+	// Normalize structs and typed slices to generic rows via JSON
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CSV rows: %v", err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, fmt.Errorf("CSV encoding requires a list of objects: %v", err)
+	}
+
+	columnSet := make(map[string]bool)
+	for _, row := range rows {
+		for key := range row {
+			columnSet[key] = true
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for key := range columnSet {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = csvValue(row[column])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// csvValue formats a single CSV cell, encoding nested values as JSON
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		nested, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(nested)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
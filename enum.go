@@ -0,0 +1,53 @@
+package sdk
+
+// EnumTypeDefinition represents a GraphQL enum type that object fields can reference
+type EnumTypeDefinition struct {
+	TypeName    string   `json:"typeName"`
+	Description string   `json:"description"`
+	Values      []string `json:"values"`
+}
+
+// NewEnumType creates an enum type definition and registers it with the current plugin instance
+func NewEnumType(typeName, description string, values ...string) EnumTypeDefinition {
+	def := EnumTypeDefinition{
+		TypeName:    typeName,
+		Description: description,
+		Values:      values,
+	}
+	if target := registrationPlugin(); target != nil {
+		target.RegisterEnumType(def)
+	}
+	return def
+}
+
+// RegisterEnumType registers an enum type so object fields can reference it with AddEnumField
+func (p *Plugin) RegisterEnumType(enumType EnumTypeDefinition) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.schemaCache = nil
+	p.enumTypes[enumType.TypeName] = enumType
+}
+
+// GetEnumType returns the enum type definition for a given name
+func (p *Plugin) GetEnumType(name string) (EnumTypeDefinition, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	enumType, exists := p.enumTypes[name]
+	return enumType, exists
+}
+
+// serializeEnumTypeDefinition converts an EnumTypeDefinition to protobuf-compatible format
+func (impl *pluginImpl) serializeEnumTypeDefinition(enumType EnumTypeDefinition) map[string]interface{} {
+	values := make([]interface{}, len(enumType.Values))
+	for i, value := range enumType.Values {
+		values[i] = value
+	}
+	return map[string]interface{}{
+		"kind":        "enum",
+		"name":        impl.plugin.namespacedTypeName(enumType.TypeName),
+		"description": enumType.Description,
+		"values":      values,
+	}
+}
@@ -0,0 +1,117 @@
+package sdk
+
+import "time"
+
+// RESTResponse is the standard REST response envelope. Handlers may return it (or a pointer
+// to it) directly; it is passed through untouched when the REST envelope is enabled.
+type RESTResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   *RESTError  `json:"error,omitempty"`
+}
+
+// RESTError is the error part of the REST response envelope
+type RESTError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+// toMap converts the envelope to a protobuf-compatible map
+func (r RESTResponse) toMap() map[string]interface{} {
+	envelope := map[string]interface{}{
+		"success": r.Success,
+	}
+	if r.Data != nil {
+		envelope["data"] = r.Data
+	}
+	if r.Error != nil {
+		restErr := map[string]interface{}{
+			"code":    r.Error.Code,
+			"message": r.Error.Message,
+		}
+		if r.Error.Details != "" {
+			restErr["details"] = r.Error.Details
+		}
+		envelope["error"] = restErr
+	}
+	return envelope
+}
+
+// SetRESTEnvelope enables or disables wrapping every REST handler result in the standard
+// {success, data} / {success, error} envelope at the Execute layer
+func (p *Plugin) SetRESTEnvelope(enabled bool) {
+	p.restEnvelope.Store(enabled)
+}
+
+// wrapRESTResult wraps a successful REST handler result in the standard envelope,
+// passing through results that already are an envelope
+func wrapRESTResult(result interface{}) interface{} {
+	switch r := result.(type) {
+	case RESTResponse:
+		return r.toMap()
+	case *RESTResponse:
+		if r != nil {
+			return r.toMap()
+		}
+	case map[string]interface{}:
+		if _, isEnvelope := r["success"].(bool); isEnvelope {
+			return r
+		}
+	}
+
+	return RESTResponse{Success: true, Data: result}.toMap()
+}
+
+// wrapRESTError builds the standard error envelope for a failed REST handler
+func wrapRESTError(err error) map[string]interface{} {
+	restErr := &RESTError{
+		Code:    GetErrorCode(err),
+		Message: GetErrorMessage(err),
+	}
+	if codedErr := GetCodedError(err); codedErr != nil {
+		restErr.Details = codedErr.Details
+	}
+	return RESTResponse{Success: false, Error: restErr}.toMap()
+}
+
+// NewRESTSuccess builds a successful REST response in the standard envelope, adding a "meta"
+// section with the server timestamp and the plugin name and version
+func NewRESTSuccess(data interface{}) map[string]interface{} {
+	response := RESTResponse{Success: true, Data: data}.toMap()
+	response["meta"] = restResponseMeta()
+	return response
+}
+
+// NewRESTError builds a failed REST response in the standard envelope, with the same "meta"
+// section as NewRESTSuccess. Return it as the handler result when the failure should still
+// reach the client as a normal response rather than an error.
+func NewRESTError(code int, message string) map[string]interface{} {
+	response := RESTResponse{Success: false, Error: &RESTError{Code: code, Message: message}}.toMap()
+	response["meta"] = restResponseMeta()
+	return response
+}
+
+// DefaultRESTTimestampFormat is the layout of the "meta.timestamp" of NewRESTSuccess and NewRESTError
+const DefaultRESTTimestampFormat = time.RFC3339
+
+// SetRESTTimestampFormat changes the time layout used for "meta.timestamp" in NewRESTSuccess
+// and NewRESTError, e.g. time.RFC3339Nano
+func (p *Plugin) SetRESTTimestampFormat(layout string) {
+	p.restTimestampFormat.Store(layout)
+}
+
+// restResponseMeta describes the responding plugin and the response time
+func restResponseMeta() map[string]interface{} {
+	layout := DefaultRESTTimestampFormat
+	meta := make(map[string]interface{})
+	if currentPlugin != nil {
+		if custom, ok := currentPlugin.restTimestampFormat.Load().(string); ok && custom != "" {
+			layout = custom
+		}
+		meta["plugin"] = currentPlugin.Name()
+		meta["version"] = currentPlugin.Version()
+	}
+	meta["timestamp"] = time.Now().UTC().Format(layout)
+	return meta
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import "errors"
+
+// errorMapping maps a sentinel error to an HTTP status code
+type errorMapping struct {
+	target error
+	code   int
+}
+
+// MapError makes Execute turn any handler error matching target (by errors.Is) into a
+// CodedError with the given HTTP status, e.g. MapError(sql.ErrNoRows, 404), so handlers can
+// return domain errors as-is. Errors that are already CodedErrors or GraphQLErrors are left
+// alone; when several targets match, the first mapping registered wins.
+func (p *Plugin) MapError(target error, code int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.errorMappings = append(p.errorMappings, errorMapping{target: target, code: code})
+}
+
+// mapError applies the MapError mappings to a handler error
+func (p *Plugin) mapError(err error) error {
+	if err == nil || IsCodedError(err) || IsGraphQLError(err) {
+		return err
+	}
+
+	p.mu.RLock()
+	mappings := p.errorMappings
+	p.mu.RUnlock()
+
+	for _, mapping := range mappings {
+		if errors.Is(err, mapping.target) {
+			return &CodedError{
+				Code:    mapping.code,
+				Message: err.Error(),
+				Err:     err,
+			}
+		}
+	}
+	return err
+}
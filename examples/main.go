@@ -232,7 +232,7 @@ func customHelloRESTHandler(ctx context.Context, args map[string]interface{}) (i
 func statusRESTHandler(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	return map[string]interface{}{
 		"status":  "running",
-		"version": "2.0.0-sdk",
+		"version": sdk.GetPluginVersionFromContext(ctx),
 		"features": []string{
 			"GraphQL Queries",
 			"GraphQL Mutations",
@@ -0,0 +1,151 @@
+package sdk
+
+// maskResultFields removes object type fields the caller isn't allowed to see from a GraphQL
+// resolver result. Fields tagged with roles (see RequireFieldRoles) are kept only when the
+// caller has at least one of them; nested objects and lists are masked by their field types.
+func (p *Plugin) maskResultFields(resolverName string, result interface{}, roles []string) interface{} {
+	types := p.roleRestrictedTypes()
+	if len(types) == 0 {
+		return result
+	}
+
+	field, exists := p.lookupField(resolverName)
+	if !exists {
+		return result
+	}
+	typeName := resultObjectTypeName(field.Type)
+	if typeName == "" {
+		return result
+	}
+
+	callerRoles := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		callerRoles[role] = true
+	}
+
+	switch r := result.(type) {
+	case PartialResult:
+		r.Data = maskValue(normalizeMaskInput(r.Data), typeName, types, callerRoles)
+		return r
+	case *PartialResult:
+		if r == nil {
+			return result
+		}
+		masked := *r
+		masked.Data = maskValue(normalizeMaskInput(r.Data), typeName, types, callerRoles)
+		return &masked
+	}
+	return maskValue(normalizeMaskInput(result), typeName, types, callerRoles)
+}
+
+// roleRestrictedTypes returns a snapshot of the object types, or nil when no field
+// requires a role so results can be returned untouched
+func (p *Plugin) roleRestrictedTypes() map[string]ObjectTypeDefinition {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	restricted := false
+	for _, objectType := range p.objectTypes {
+		for _, fieldDef := range objectType.Fields {
+			if len(fieldDef.Roles) > 0 {
+				restricted = true
+				break
+			}
+		}
+		if restricted {
+			break
+		}
+	}
+	if !restricted {
+		return nil
+	}
+
+	types := make(map[string]ObjectTypeDefinition, len(p.objectTypes))
+	for name, objectType := range p.objectTypes {
+		types[name] = objectType
+	}
+	return types
+}
+
+// resultObjectTypeName unwraps list and non-null wrappers and returns the object type a
+// resolver returns, or "" for scalar results
+func resultObjectTypeName(fieldType interface{}) string {
+	switch t := fieldType.(type) {
+	case string:
+		return objectTypeReference(t)
+	case GraphQLTypeDefinition:
+		if t.OfType != nil && (t.Kind == "list" || t.Kind == "non_null") {
+			return resultObjectTypeName(*t.OfType)
+		}
+		if t.Kind == "object" {
+			return objectTypeReference(t.Name)
+		}
+	case *GraphQLTypeDefinition:
+		if t != nil {
+			return resultObjectTypeName(*t)
+		}
+	}
+	return ""
+}
+
+// normalizeMaskInput converts structs and typed slices to their JSON form so their
+// fields can be masked by name
+func normalizeMaskInput(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return value
+	}
+	if normalized := toJSONCompatible(value); normalized != nil {
+		return normalized
+	}
+	return value
+}
+
+// maskValue copies value with unauthorized fields of typeName removed
+func maskValue(value interface{}, typeName string, types map[string]ObjectTypeDefinition, callerRoles map[string]bool) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskValue(item, typeName, types, callerRoles)
+		}
+		return masked
+
+	case map[string]interface{}:
+		objectType, exists := types[typeName]
+		if !exists {
+			return v
+		}
+		masked := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			fieldDef, known := objectType.Fields[key]
+			if !known {
+				masked[key] = fieldValue
+				continue
+			}
+			if !hasAnyRole(callerRoles, fieldDef.Roles) {
+				continue
+			}
+			if ref := objectTypeReference(fieldDef.Type); ref != "" && !fieldDef.Enum {
+				fieldValue = maskValue(fieldValue, ref, types, callerRoles)
+			}
+			masked[key] = fieldValue
+		}
+		return masked
+	}
+	return value
+}
+
+// hasAnyRole reports whether the caller has one of the required roles; no required roles
+// means the field is public
+func hasAnyRole(callerRoles map[string]bool, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, role := range required {
+		if callerRoles[role] {
+			return true
+		}
+	}
+	return false
+}
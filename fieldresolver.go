@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"log"
+	"strings"
+)
+
+// FieldResolverName is the function name the host executes to resolve a single object type
+// field, e.g. "User.fullName"
+func FieldResolverName(typeName, fieldName string) string {
+	return typeName + "." + fieldName
+}
+
+// AddResolvedField adds a field computed by its own resolver instead of being read from the
+// parent object, e.g. a derived fullName or lazily fetched posts. typeRef uses the GraphQL
+// notation ("String", "Post!", "[Post!]!"). The resolver is registered with the current plugin
+// on Build under FieldResolverName; the host executes it as a graphql_query with the parent
+// object in the "parent" context value, see GetParent.
+func (b *ObjectTypeBuilder) AddResolvedField(name, description, typeRef string, resolver ResolverFunc) *ObjectTypeBuilder {
+	fieldDef := ObjectFieldDef{
+		Description: description,
+		Nullable:    !strings.HasSuffix(typeRef, "!"),
+		Resolved:    true,
+	}
+
+	baseType := strings.TrimSuffix(typeRef, "!")
+	if strings.HasPrefix(baseType, "[") && strings.HasSuffix(baseType, "]") {
+		itemType := strings.TrimSuffix(strings.TrimPrefix(baseType, "["), "]")
+		fieldDef.List = true
+		fieldDef.ListOfNonNull = strings.HasSuffix(itemType, "!")
+		baseType = strings.TrimSuffix(itemType, "!")
+	}
+	fieldDef.Type = baseType
+
+	b.setField(name, fieldDef)
+	if b.resolvers == nil {
+		b.resolvers = make(map[string]ResolverFunc)
+	}
+	b.resolvers[name] = resolver
+	return b
+}
+
+// RegisterFieldResolver registers the resolver for a single object type field
+func (p *Plugin) RegisterFieldResolver(typeName, fieldName string, resolver ResolverFunc) {
+	if resolver == nil {
+		log.Printf("SDK Warning: Field resolver '%s' registered with a nil resolver", FieldResolverName(typeName, fieldName))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fieldResolvers[FieldResolverName(typeName, fieldName)] = resolver
+}
+
+// lookupFieldResolver finds a field resolver by its "TypeName.fieldName" name
+func (p *Plugin) lookupFieldResolver(name string) (ResolverFunc, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	resolver, exists := p.fieldResolvers[name]
+	return resolver, exists
+}
+
+// GetParent returns the parent object a field resolver is resolving a field of
+func GetParent(args map[string]interface{}) map[string]interface{} {
+	parent, _ := args["context_parent"].(map[string]interface{})
+	return parent
+}
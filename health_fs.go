@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"os"
+	"runtime"
+)
+
+// SetHealthDiskPath makes the health check report disk usage of the filesystem holding path
+// (e.g. a data or upload directory). It is supported on Linux and macOS and omitted elsewhere.
+func (p *Plugin) SetHealthDiskPath(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthDiskPath = path
+}
+
+// openFDCount returns the number of file descriptors open in this process, or -1 when the
+// platform doesn't expose them
+func openFDCount() int {
+	var dir string
+	switch runtime.GOOS {
+	case "linux":
+		dir = "/proc/self/fd"
+	case "darwin", "freebsd":
+		dir = "/dev/fd"
+	default:
+		return -1
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return -1
+	}
+	// Reading the directory itself holds one descriptor open
+	return len(entries) - 1
+}
+
+// diskUsageInfo describes the filesystem holding path, or returns nil when unsupported
+func diskUsageInfo(path string) map[string]interface{} {
+	total, free, ok := diskUsage(path)
+	if !ok || total == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"path":         path,
+		"total_bytes":  total,
+		"free_bytes":   free,
+		"used_percent": float64(total-free) / float64(total) * 100,
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package sdk
+
+// diskUsage is unsupported on this platform
+func diskUsage(path string) (total, free uint64, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import "fmt"
+
+// HealthThresholds are resource limits above which the health check reports "degraded".
+// A zero field disables that threshold.
+type HealthThresholds struct {
+	MaxGoroutines int
+	MaxHeapBytes  uint64
+}
+
+// SetHealthThresholds makes the health check report "degraded" when a resource exceeds its
+// threshold, listing each tripped threshold with its limit and current value
+func (p *Plugin) SetHealthThresholds(thresholds HealthThresholds) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthThresholds = thresholds
+}
+
+// check compares the current resource usage against the thresholds, returning the details of
+// every configured threshold and the reasons for those that tripped
+func (t HealthThresholds) check(goroutines int, heapBytes uint64) (map[string]interface{}, []interface{}) {
+	details := make(map[string]interface{})
+	var reasons []interface{}
+
+	if t.MaxGoroutines > 0 {
+		exceeded := goroutines > t.MaxGoroutines
+		details["goroutines"] = map[string]interface{}{
+			"limit":    t.MaxGoroutines,
+			"current":  goroutines,
+			"exceeded": exceeded,
+		}
+		if exceeded {
+			reasons = append(reasons, fmt.Sprintf("goroutines %d exceed limit %d", goroutines, t.MaxGoroutines))
+		}
+	}
+	if t.MaxHeapBytes > 0 {
+		exceeded := heapBytes > t.MaxHeapBytes
+		details["heap_bytes"] = map[string]interface{}{
+			"limit":    t.MaxHeapBytes,
+			"current":  heapBytes,
+			"exceeded": exceeded,
+		}
+		if exceeded {
+			reasons = append(reasons, fmt.Sprintf("heap %d bytes exceeds limit %d bytes", heapBytes, t.MaxHeapBytes))
+		}
+	}
+	return details, reasons
+}
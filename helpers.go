@@ -2,8 +2,11 @@ package sdk
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -46,6 +49,16 @@ func FloatField(description string) GraphQLField {
 	return Field("Float", description)
 }
 
+// IDField creates an ID type GraphQL field; use NonNullIDField for identifiers clients cache by
+func IDField(description string) GraphQLField {
+	return Field("ID", description)
+}
+
+// NonNullIDField creates an ID! type GraphQL field
+func NonNullIDField(description string) GraphQLField {
+	return NonNullField("ID", description)
+}
+
 // ListField creates a list type GraphQL field
 func ListField(itemType, description string) GraphQLField {
 	return GraphQLField{
@@ -82,15 +95,43 @@ type ObjectTypeDefinition struct {
 	TypeName    string                    `json:"typeName"`
 	Description string                    `json:"description"`
 	Fields      map[string]ObjectFieldDef `json:"fields"`
+	FieldOrder  []string                  `json:"fieldOrder,omitempty"` // Declaration order, recorded by ObjectTypeBuilder
+}
+
+// OrderedFieldNames returns the field names in declaration order. Fields missing from
+// FieldOrder, e.g. in definitions built by hand, follow sorted by name.
+func (d ObjectTypeDefinition) OrderedFieldNames() []string {
+	names := make([]string, 0, len(d.Fields))
+	seen := make(map[string]bool, len(d.Fields))
+	for _, name := range d.FieldOrder {
+		if _, exists := d.Fields[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range d.Fields {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
 }
 
 // ObjectFieldDef represents a field within an object type
 type ObjectFieldDef struct {
-	Type          string `json:"type"`
-	Description   string `json:"description"`
-	Nullable      bool   `json:"nullable"`
-	List          bool   `json:"list"`
-	ListOfNonNull bool   `json:"listOfNonNull"`
+	Type              string   `json:"type"`
+	Description       string   `json:"description"`
+	Nullable          bool     `json:"nullable"`
+	List              bool     `json:"list"`
+	ListOfNonNull     bool     `json:"listOfNonNull"`
+	IsDeprecated      bool     `json:"deprecated,omitempty"`
+	DeprecationReason string   `json:"deprecationReason,omitempty"`
+	Enum              bool     `json:"enum,omitempty"`     // Type names a registered enum type rather than an object type
+	Roles             []string `json:"roles,omitempty"`    // Callers need at least one of these roles to see the field
+	Resolved          bool     `json:"resolved,omitempty"` // Computed by a field resolver, see AddResolvedField
 }
 
 // ComplexObjectField creates a GraphQL field that returns a complex object type
@@ -178,11 +219,13 @@ func serializeObjectFields(fields map[string]ObjectFieldDef) map[string]interfac
 	result := make(map[string]interface{})
 	for fieldName, fieldDef := range fields {
 		result[fieldName] = map[string]interface{}{
-			"type":          fieldDef.Type,
-			"description":   fieldDef.Description,
-			"nullable":      fieldDef.Nullable,
-			"list":          fieldDef.List,
-			"listOfNonNull": fieldDef.ListOfNonNull,
+			"type":              fieldDef.Type,
+			"description":       fieldDef.Description,
+			"nullable":          fieldDef.Nullable,
+			"list":              fieldDef.List,
+			"listOfNonNull":     fieldDef.ListOfNonNull,
+			"deprecated":        fieldDef.IsDeprecated,
+			"deprecationReason": fieldDef.DeprecationReason,
 		}
 	}
 	return result
@@ -217,54 +260,99 @@ func NewArrayObjectTypeWithArgs(objectDef ObjectTypeDefinition, args map[string]
 
 // ObjectTypeBuilder helps build complex object type definitions
 type ObjectTypeBuilder struct {
-	def ObjectTypeDefinition
+	def       ObjectTypeDefinition
+	resolvers map[string]ResolverFunc // Field resolvers added with AddResolvedField
 }
 
 // AddStringField adds a string field to the object type
 func (b *ObjectTypeBuilder) AddStringField(name, description string, nullable bool) *ObjectTypeBuilder {
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          "String",
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
 	return b
 }
 
+// AddStringFieldWithRoles adds a string field that is removed from results unless the caller
+// has at least one of the given roles
+func (b *ObjectTypeBuilder) AddStringFieldWithRoles(name, description string, nullable bool, roles ...string) *ObjectTypeBuilder {
+	b.AddStringField(name, description, nullable)
+	return b.RequireFieldRoles(name, roles...)
+}
+
 // AddIntField adds an integer field to the object type
 func (b *ObjectTypeBuilder) AddIntField(name, description string, nullable bool) *ObjectTypeBuilder {
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          "Int",
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
 	return b
 }
 
 // AddBooleanField adds a boolean field to the object type
 func (b *ObjectTypeBuilder) AddBooleanField(name, description string, nullable bool) *ObjectTypeBuilder {
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          "Boolean",
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
 	return b
 }
 
 // AddFloatField adds a float field to the object type
 func (b *ObjectTypeBuilder) AddFloatField(name, description string, nullable bool) *ObjectTypeBuilder {
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          "Float",
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
+	return b
+}
+
+// AddIDField adds an ID field to the object type
+func (b *ObjectTypeBuilder) AddIDField(name, description string, nullable bool) *ObjectTypeBuilder {
+	b.setField(name, ObjectFieldDef{
+		Type:          "ID",
+		Description:   description,
+		Nullable:      nullable,
+		List:          false,
+		ListOfNonNull: false,
+	})
+	return b
+}
+
+// AddDateTimeField adds a DateTime field (an RFC 3339 string) to the object type
+func (b *ObjectTypeBuilder) AddDateTimeField(name, description string, nullable bool) *ObjectTypeBuilder {
+	b.setField(name, ObjectFieldDef{
+		Type:          "DateTime",
+		Description:   description,
+		Nullable:      nullable,
+		List:          false,
+		ListOfNonNull: false,
+	})
+	return b
+}
+
+// AddEnumField adds a field whose value is one of a registered enum type's values
+func (b *ObjectTypeBuilder) AddEnumField(name, description, enumName string, nullable bool) *ObjectTypeBuilder {
+	b.setField(name, ObjectFieldDef{
+		Type:          enumName,
+		Description:   description,
+		Nullable:      nullable,
+		List:          false,
+		ListOfNonNull: false,
+		Enum:          true,
+	})
 	return b
 }
 
@@ -283,25 +371,35 @@ func (b *ObjectTypeBuilder) AddObjectField(name, description string, objectType
 		typeName = fmt.Sprintf("%v", objectType)
 	}
 
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          typeName,
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
 	return b
 }
 
+// AddSelfReferenceField adds a field whose type is the object type being built (e.g. Category.parent)
+func (b *ObjectTypeBuilder) AddSelfReferenceField(name, description string, nullable bool) *ObjectTypeBuilder {
+	return b.AddObjectField(name, description, b.def.TypeName, nullable)
+}
+
+// AddSelfReferenceListField adds a list field of the object type being built (e.g. Comment.replies)
+func (b *ObjectTypeBuilder) AddSelfReferenceListField(name, description string, nullable, listOfNonNull bool) *ObjectTypeBuilder {
+	return b.AddObjectListField(name, description, b.def.TypeName, nullable, listOfNonNull)
+}
+
 // AddListField adds an array/list field to the object type
 func (b *ObjectTypeBuilder) AddListField(name, description, itemType string, nullable, listOfNonNull bool) *ObjectTypeBuilder {
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          itemType,
 		Description:   description,
 		Nullable:      nullable,
 		List:          true,
 		ListOfNonNull: listOfNonNull,
-	}
+	})
 	return b
 }
 
@@ -315,6 +413,16 @@ func (b *ObjectTypeBuilder) AddIntListField(name, description string, nullable,
 	return b.AddListField(name, description, "Int", nullable, listOfNonNull)
 }
 
+// AddFloatListField adds a list of floats field
+func (b *ObjectTypeBuilder) AddFloatListField(name, description string, nullable, listOfNonNull bool) *ObjectTypeBuilder {
+	return b.AddListField(name, description, "Float", nullable, listOfNonNull)
+}
+
+// AddBooleanListField adds a list of booleans field
+func (b *ObjectTypeBuilder) AddBooleanListField(name, description string, nullable, listOfNonNull bool) *ObjectTypeBuilder {
+	return b.AddListField(name, description, "Boolean", nullable, listOfNonNull)
+}
+
 // AddObjectListField adds a list of objects field
 func (b *ObjectTypeBuilder) AddObjectListField(name, description string, objectType interface{}, nullable, listOfNonNull bool) *ObjectTypeBuilder {
 	var typeName string
@@ -354,13 +462,13 @@ func (b *ObjectTypeBuilder) AddJSONField(name, description string, structuredTyp
 		fieldType = "JSON_Generic"
 	}
 
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          fieldType,
 		Description:   description,
 		Nullable:      nullable,
 		List:          false,
 		ListOfNonNull: false,
-	}
+	})
 	return b
 }
 
@@ -371,12 +479,42 @@ func (b *ObjectTypeBuilder) AddJSONArrayField(name, description string, itemType
 		fieldType += "!"
 	}
 
-	b.def.Fields[name] = ObjectFieldDef{
+	b.setField(name, ObjectFieldDef{
 		Type:          fieldType,
 		Description:   description,
 		Nullable:      nullable,
 		List:          true,
 		ListOfNonNull: false,
+	})
+	return b
+}
+
+// DeprecateField marks a previously added field as deprecated with the given reason
+func (b *ObjectTypeBuilder) DeprecateField(name, reason string) *ObjectTypeBuilder {
+	if fieldDef, exists := b.def.Fields[name]; exists {
+		if reason == "" {
+			reason = DefaultDeprecationReason
+		}
+		fieldDef.IsDeprecated = true
+		fieldDef.DeprecationReason = reason
+		b.def.Fields[name] = fieldDef
+	}
+	return b
+}
+
+// setField adds or replaces a field, recording the order fields are first declared in
+func (b *ObjectTypeBuilder) setField(name string, fieldDef ObjectFieldDef) {
+	if _, exists := b.def.Fields[name]; !exists {
+		b.def.FieldOrder = append(b.def.FieldOrder, name)
+	}
+	b.def.Fields[name] = fieldDef
+}
+
+// RequireFieldRoles restricts a previously added field to callers with at least one of the given roles
+func (b *ObjectTypeBuilder) RequireFieldRoles(name string, roles ...string) *ObjectTypeBuilder {
+	if fieldDef, exists := b.def.Fields[name]; exists && len(roles) > 0 {
+		fieldDef.Roles = append([]string(nil), roles...)
+		b.def.Fields[name] = fieldDef
 	}
 	return b
 }
@@ -384,8 +522,11 @@ func (b *ObjectTypeBuilder) AddJSONArrayField(name, description string, itemType
 // Build returns the completed object type definition
 func (b *ObjectTypeBuilder) Build() ObjectTypeDefinition {
 	// Automatically register the object type with the current plugin instance
-	if currentPlugin != nil {
-		currentPlugin.RegisterObjectType(b.def)
+	if target := registrationPlugin(); target != nil {
+		target.RegisterObjectType(b.def)
+		for fieldName, resolver := range b.resolvers {
+			target.RegisterFieldResolver(b.def.TypeName, fieldName, resolver)
+		}
 	}
 	return b.def
 }
@@ -432,6 +573,9 @@ func ErrorObjectType() ObjectTypeDefinition {
 
 // ResponseWrapperType creates a generic response wrapper type
 func ResponseWrapperType(dataType string) ObjectTypeDefinition {
+	// Make sure the nested Error type is registered
+	ErrorObjectType()
+
 	return NewObjectType("Response", "A generic response wrapper").
 		AddBooleanField("success", "Whether the operation was successful", false).
 		AddStringField("message", "Response message", true).
@@ -440,6 +584,20 @@ func ResponseWrapperType(dataType string) ObjectTypeDefinition {
 		Build()
 }
 
+// ListResponseWrapperType creates a response wrapper for list data, e.g. ListResponseWrapperType("User")
+// builds "UserListResponse" with data of type [User!]
+func ListResponseWrapperType(itemType string) ObjectTypeDefinition {
+	// Make sure the nested Error type is registered
+	ErrorObjectType()
+
+	return NewObjectType(itemType+"ListResponse", "A response wrapper for a list of "+itemType).
+		AddBooleanField("success", "Whether the operation was successful", false).
+		AddStringField("message", "Response message", true).
+		AddObjectListField("data", "The response data", itemType, true, true).
+		AddObjectListField("errors", "List of errors if any", "Error", true, false).
+		Build()
+}
+
 // PaginatedResponseType creates a paginated response type
 func PaginatedResponseType(itemType string) ObjectTypeDefinition {
 	// For now, create a simplified paginated response that doesn't reference other complex types
@@ -457,6 +615,20 @@ func PaginatedResponseType(itemType string) ObjectTypeDefinition {
 		Build()
 }
 
+// TypedPaginatedResponseType creates a paginated response type whose items are a list of the
+// named item object type, e.g. TypedPaginatedResponseType("User") builds "UserPaginatedResponse"
+// with items of type [User!]! and a nested PaginationInfo object
+func TypedPaginatedResponseType(itemType string) ObjectTypeDefinition {
+	paginationInfo := PaginationInfoType()
+
+	return NewObjectType(itemType+"PaginatedResponse", "A paginated response of "+itemType).
+		AddObjectListField("items", "List of items", itemType, false, true).
+		AddObjectField("pagination", "Pagination information", paginationInfo, false).
+		AddBooleanField("success", "Whether the operation was successful", false).
+		AddStringField("message", "Response message", true).
+		Build()
+}
+
 // =====================================================
 // BACKWARD COMPATIBILITY - OLD OBJECTFIELD
 // =====================================================
@@ -471,12 +643,35 @@ func ObjectField(description string, properties map[string]interface{}) GraphQLF
 	return field
 }
 
-// Arg creates a GraphQL argument definition
+// Arg creates a GraphQL argument definition. "type" holds the type string (e.g. "[String!]!")
+// and "typeDef" the same type as a structured GraphQLTypeDefinition, the representation fields use.
 func Arg(argType, description string) map[string]interface{} {
-	return map[string]interface{}{
-		"type":        argType,
+	return TypedArg(parseArgTypeString(argType), description)
+}
+
+// TypedArg creates a GraphQL argument definition from a structured type definition
+func TypedArg(typeDef GraphQLTypeDefinition, description string) map[string]interface{} {
+	arg := map[string]interface{}{
 		"description": description,
 	}
+	setArgType(arg, typeDef)
+	return arg
+}
+
+// setArgType stores an argument's type as both the "type" string and the "typeDef" definition
+func setArgType(arg map[string]interface{}, typeDef GraphQLTypeDefinition) {
+	arg["type"] = typeDefinitionString(typeDef)
+	arg["typeDef"] = typeDef
+}
+
+// DeprecatedArg marks an argument definition as deprecated with the given reason
+func DeprecatedArg(arg map[string]interface{}, reason string) map[string]interface{} {
+	if reason == "" {
+		reason = DefaultDeprecationReason
+	}
+	arg["deprecated"] = true
+	arg["deprecationReason"] = reason
+	return arg
 }
 
 // StringArg creates a String type argument
@@ -499,32 +694,70 @@ func FloatArg(description string) map[string]interface{} {
 	return Arg("Float", description)
 }
 
+// IDArg creates an ID type argument
+func IDArg(description string) map[string]interface{} {
+	return Arg("ID", description)
+}
+
 // NonNullArg creates a non-null type argument
 func NonNullArg(argType, description string) map[string]interface{} {
-	return Arg(argType+"!", description)
+	return TypedArg(createNonNullType(parseArgTypeString(argType)), description)
 }
 
 // ObjectArg creates an Object type argument with properties
 func ObjectArg(description string, properties map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"type":        "Object",
-		"description": description,
-		"properties":  properties,
-	}
+	arg := Arg("Object", description)
+	arg["properties"] = properties
+	return arg
 }
 
 // ListArg creates a list type argument
 func ListArg(itemType, description string) map[string]interface{} {
-	return Arg("["+itemType+"]", description)
+	return TypedArg(createListType(parseArgTypeString(itemType)), description)
+}
+
+// ListArgOption configures element and length constraints for ListArgOf
+type ListArgOption func(arg map[string]interface{})
+
+// NonNullItems requires every list element to be non-null, i.e. [Type!]
+func NonNullItems() ListArgOption {
+	return func(arg map[string]interface{}) {
+		arg["nonNullItems"] = true
+	}
+}
+
+// MinItems requires the list to contain at least n elements
+func MinItems(n int) ListArgOption {
+	return func(arg map[string]interface{}) {
+		arg["minItems"] = n
+	}
+}
+
+// MaxItems allows the list to contain at most n elements
+func MaxItems(n int) ListArgOption {
+	return func(arg map[string]interface{}) {
+		arg["maxItems"] = n
+	}
+}
+
+// ListArgOf creates a list type argument with element and length constraints,
+// which are enforced before the resolver runs
+func ListArgOf(itemType, description string, opts ...ListArgOption) map[string]interface{} {
+	arg := ListArg(itemType, description)
+	for _, opt := range opts {
+		opt(arg)
+	}
+	if nonNull, _ := arg["nonNullItems"].(bool); nonNull {
+		setArgType(arg, createListType(createNonNullType(parseArgTypeString(itemType))))
+	}
+	return arg
 }
 
 // ArrayObjectArg creates an array of objects argument with defined properties
 func ArrayObjectArg(description string, properties map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"type":        "[Object]",
-		"description": description,
-		"properties":  properties,
-	}
+	arg := ListArg("Object", description)
+	arg["properties"] = properties
+	return arg
 }
 
 // Property creates a property definition for object types
@@ -555,6 +788,74 @@ func FloatProperty(description string) map[string]interface{} {
 	return Property("Float", description)
 }
 
+// ArgObjectBuilder helps build the properties of Object arguments,
+// mirroring ObjectTypeBuilder on the input side
+type ArgObjectBuilder struct {
+	description string
+	properties  map[string]interface{}
+}
+
+// NewArgObject creates a new argument object builder
+func NewArgObject(description string) *ArgObjectBuilder {
+	return &ArgObjectBuilder{
+		description: description,
+		properties:  make(map[string]interface{}),
+	}
+}
+
+// AddProp adds a property of the given type to the argument object
+func (b *ArgObjectBuilder) AddProp(name, propType, description string) *ArgObjectBuilder {
+	b.properties[name] = Property(propType, description)
+	return b
+}
+
+// AddStringProp adds a String property to the argument object
+func (b *ArgObjectBuilder) AddStringProp(name, description string) *ArgObjectBuilder {
+	return b.AddProp(name, "String", description)
+}
+
+// AddIntProp adds an Int property to the argument object
+func (b *ArgObjectBuilder) AddIntProp(name, description string) *ArgObjectBuilder {
+	return b.AddProp(name, "Int", description)
+}
+
+// AddBooleanProp adds a Boolean property to the argument object
+func (b *ArgObjectBuilder) AddBooleanProp(name, description string) *ArgObjectBuilder {
+	return b.AddProp(name, "Boolean", description)
+}
+
+// AddFloatProp adds a Float property to the argument object
+func (b *ArgObjectBuilder) AddFloatProp(name, description string) *ArgObjectBuilder {
+	return b.AddProp(name, "Float", description)
+}
+
+// AddObjectProp adds a nested object property built with another ArgObjectBuilder
+func (b *ArgObjectBuilder) AddObjectProp(name string, object *ArgObjectBuilder) *ArgObjectBuilder {
+	b.properties[name] = object.Build()
+	return b
+}
+
+// AddListProp adds a list property with the given item type, e.g. "String" for [String]
+func (b *ArgObjectBuilder) AddListProp(name, itemType, description string) *ArgObjectBuilder {
+	return b.AddProp(name, "["+itemType+"]", description)
+}
+
+// AddObjectListProp adds a list of objects property built with another ArgObjectBuilder
+func (b *ArgObjectBuilder) AddObjectListProp(name string, object *ArgObjectBuilder) *ArgObjectBuilder {
+	b.properties[name] = ArrayObjectArg(object.description, object.properties)
+	return b
+}
+
+// Properties returns the properties map for use with ObjectArg or ArrayObjectArg
+func (b *ArgObjectBuilder) Properties() map[string]interface{} {
+	return b.properties
+}
+
+// Build returns the Object argument definition
+func (b *ArgObjectBuilder) Build() map[string]interface{} {
+	return ObjectArg(b.description, b.properties)
+}
+
 // RESTEndpointBuilder helps build REST endpoint definitions
 type RESTEndpointBuilder struct {
 	endpoint RESTEndpoint
@@ -572,6 +873,12 @@ func NewRESTEndpoint(method, path, description string) *RESTEndpointBuilder {
 	}
 }
 
+// WithDescription replaces the description of the REST endpoint
+func (b *RESTEndpointBuilder) WithDescription(description string) *RESTEndpointBuilder {
+	b.endpoint.Description = description
+	return b
+}
+
 // WithRequestSchema adds request schema to the REST endpoint
 func (b *RESTEndpointBuilder) WithRequestSchema(schema map[string]interface{}) *RESTEndpointBuilder {
 	b.endpoint.Schema["request"] = schema
@@ -584,8 +891,98 @@ func (b *RESTEndpointBuilder) WithResponseSchema(schema map[string]interface{})
 	return b
 }
 
-// Build returns the constructed REST endpoint
+// RequireAuth marks the REST endpoint as requiring an authenticated caller.
+// The requirement is serialized into the endpoint schema so the host can enforce it.
+func (b *RESTEndpointBuilder) RequireAuth() *RESTEndpointBuilder {
+	auth, _ := b.endpoint.Schema["auth"].(map[string]interface{})
+	if auth == nil {
+		auth = map[string]interface{}{
+			"scopes": []interface{}{},
+		}
+		b.endpoint.Schema["auth"] = auth
+	}
+	auth["required"] = true
+	return b
+}
+
+// RequireScopes marks the REST endpoint as requiring an authenticated caller holding all of the given scopes
+func (b *RESTEndpointBuilder) RequireScopes(scopes ...string) *RESTEndpointBuilder {
+	b.RequireAuth()
+	auth := b.endpoint.Schema["auth"].(map[string]interface{})
+
+	// Use []interface{} so the schema stays protobuf-compatible
+	existing, _ := auth["scopes"].([]interface{})
+	for _, scope := range scopes {
+		existing = append(existing, scope)
+	}
+	auth["scopes"] = existing
+	return b
+}
+
+// WithRateLimit declares a per-caller rate limit the host should enforce for the REST endpoint
+func (b *RESTEndpointBuilder) WithRateLimit(requestsPerMinute int) *RESTEndpointBuilder {
+	b.endpoint.Schema["rateLimit"] = map[string]interface{}{
+		"requestsPerMinute": requestsPerMinute,
+	}
+	return b
+}
+
+// WithMaxRequestBytes overrides the plugin-wide request size limit for endpoints that
+// legitimately accept large payloads such as uploads; a negative value disables the limit
+func (b *RESTEndpointBuilder) WithMaxRequestBytes(n int64) *RESTEndpointBuilder {
+	b.endpoint.MaxRequestBytes = n
+	b.endpoint.Schema["maxRequestBytes"] = n
+	return b
+}
+
+// WithExample attaches an example request/response pair to the REST endpoint for generated docs.
+// Either side may be nil; values are normalized to JSON types so the schema stays protobuf-compatible.
+func (b *RESTEndpointBuilder) WithExample(request, response interface{}) *RESTEndpointBuilder {
+	example := make(map[string]interface{})
+	if request != nil {
+		example["request"] = toJSONCompatible(request)
+	}
+	if response != nil {
+		example["response"] = toJSONCompatible(response)
+	}
+
+	examples, _ := b.endpoint.Schema["examples"].([]interface{})
+	b.endpoint.Schema["examples"] = append(examples, example)
+	return b
+}
+
+// WithTags adds documentation tags (e.g. "users", "admin") to the REST endpoint
+func (b *RESTEndpointBuilder) WithTags(tags ...string) *RESTEndpointBuilder {
+	// Use []interface{} so the schema stays protobuf-compatible
+	existing, _ := b.endpoint.Schema["tags"].([]interface{})
+	for _, tag := range tags {
+		existing = append(existing, tag)
+	}
+	b.endpoint.Schema["tags"] = existing
+	return b
+}
+
+// knownHTTPMethods are the HTTP verbs a REST endpoint may be registered under
+var knownHTTPMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"PATCH":   true,
+	"DELETE":  true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// Build returns the constructed REST endpoint.
+// The method is upper-cased; an unknown method or a path without a leading slash is logged as a warning.
 func (b *RESTEndpointBuilder) Build() RESTEndpoint {
+	b.endpoint.Method = strings.ToUpper(strings.TrimSpace(b.endpoint.Method))
+	if !knownHTTPMethods[b.endpoint.Method] {
+		log.Printf("SDK Warning: REST endpoint '%s' has unknown HTTP method '%s'", b.endpoint.Path, b.endpoint.Method)
+	}
+	if !strings.HasPrefix(b.endpoint.Path, "/") {
+		log.Printf("SDK Warning: REST endpoint path '%s' should start with '/'", b.endpoint.Path)
+	}
 	return b.endpoint
 }
 
@@ -669,31 +1066,174 @@ func NumberSchema(description string) map[string]interface{} {
 // ArgParser helps parse and convert GraphQL arguments based on field definitions
 type ArgParser struct {
 	fieldDef GraphQLField
+	strict   bool
+	// plugin provides the registered argument transformers
+	plugin *Plugin
 }
 
-// NewArgParser creates a new argument parser for a GraphQL field
+// NewArgParser creates a new argument parser for a GraphQL field, using the transformers
+// registered on the current plugin
 func NewArgParser(field GraphQLField) *ArgParser {
-	return &ArgParser{fieldDef: field}
+	return &ArgParser{fieldDef: field, plugin: currentPlugin}
+}
+
+// newArgParser creates an argument parser that uses the transformers registered on p
+func (p *Plugin) newArgParser(field GraphQLField) *ArgParser {
+	return &ArgParser{fieldDef: field, plugin: p}
+}
+
+// StrictObjects makes the parser drop properties of Object arguments that aren't in the
+// argument's properties definition; by default unknown properties are kept as-is
+func (p *ArgParser) StrictObjects() *ArgParser {
+	p.strict = true
+	return p
 }
 
-// ParseArgs converts raw GraphQL arguments to properly typed Go values based on field definition
+// ParseArgs converts raw GraphQL arguments to properly typed Go values based on field definition.
+// The result is a deep copy: resolvers may mutate it without affecting rawArgs.
 func (p *ArgParser) ParseArgs(rawArgs map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
 
 	for argName, argDef := range p.fieldDef.Args {
 		if rawValue, exists := rawArgs[argName]; exists && rawValue != nil {
 			result[argName] = p.parseValue(rawValue, argDef)
+		} else if defaultValue, hasDefault := argDefaultValue(argDef); hasDefault {
+			result[argName] = p.parseValue(defaultValue, argDef)
 		}
 	}
 
-	return result
+	// Pass-through branches return nested maps and slices of rawArgs, so detach them
+	return deepCopyValue(result, make(map[uintptr]interface{})).(map[string]interface{})
+}
+
+// deepCopyValue copies nested maps and []interface{} slices. copies maps the address of each
+// map already copied to its copy, so shared and cyclic references are preserved, not re-copied.
+func deepCopyValue(value interface{}, copies map[uintptr]interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		ptr := reflect.ValueOf(v).Pointer()
+		if copied, exists := copies[ptr]; exists {
+			return copied
+		}
+		copied := make(map[string]interface{}, len(v))
+		copies[ptr] = copied
+		for key, val := range v {
+			copied[key] = deepCopyValue(val, copies)
+		}
+		return copied
+
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopyValue(item, copies)
+		}
+		return copied
+	}
+	return value
+}
+
+// CheckConstraints enforces the list constraints declared with ListArgOf, returning a
+// BadRequestError naming the first offending argument
+func (p *ArgParser) CheckConstraints(rawArgs map[string]interface{}) error {
+	for argName, argDef := range p.fieldDef.Args {
+		argDefMap, ok := argDef.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rawValue, exists := rawArgs[argName]
+		if !exists || rawValue == nil {
+			continue
+		}
+		if message := listConstraintViolation(rawValue, argDefMap); message != "" {
+			return BadRequestError(fmt.Sprintf("Invalid argument '%s'", argName), message)
+		}
+	}
+	return nil
+}
+
+// CheckUnknownArgs returns a BadRequestError naming the first argument that the field doesn't
+// declare. Host-injected keys carrying a REST or context prefix (context_, query_, ...) are exempt.
+func (p *ArgParser) CheckUnknownArgs(rawArgs map[string]interface{}) error {
+	if unknown := p.unknownArgs(rawArgs); len(unknown) > 0 {
+		return BadRequestError(fmt.Sprintf("Unknown argument '%s'", unknown[0]), "argument is not declared by the field")
+	}
+	return nil
+}
+
+// unknownArgs lists, sorted, the argument names in rawArgs that the field doesn't declare
+func (p *ArgParser) unknownArgs(rawArgs map[string]interface{}) []string {
+	var unknown []string
+	for argName := range rawArgs {
+		if _, declared := p.fieldDef.Args[argName]; declared || isHostArgKey(argName) {
+			continue
+		}
+		unknown = append(unknown, argName)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// isHostArgKey reports whether an argument key was injected by the host rather than sent by the client
+func isHostArgKey(key string) bool {
+	for _, prefix := range argKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// listConstraintViolation describes how a list value breaks the constraints in argDef, or returns ""
+func listConstraintViolation(rawValue interface{}, argDef map[string]interface{}) string {
+	if rawValue == nil || !strings.HasPrefix(argTypeString(argDef), "[") {
+		return ""
+	}
+	// Check the list the resolver will see, so a single value coerced to a one-element list
+	// is held to the same limits
+	items := coerceListInput(rawValue)
+
+	if minItems, ok := argDef["minItems"].(int); ok && len(items) < minItems {
+		return fmt.Sprintf("expected at least %d items, got %d", minItems, len(items))
+	}
+	if maxItems, ok := argDef["maxItems"].(int); ok && len(items) > maxItems {
+		return fmt.Sprintf("expected at most %d items, got %d", maxItems, len(items))
+	}
+	if nonNull, _ := argDef["nonNullItems"].(bool); nonNull {
+		for i, item := range items {
+			if item == nil {
+				return fmt.Sprintf("item %d must not be null", i)
+			}
+		}
+	}
+
+	return ""
 }
 
-// parseValue converts a raw value based on argument definition
+// parseValue converts a raw value based on argument definition, then applies the
+// transformer registered for the argument (see RegisterArgTransformer)
 func (p *ArgParser) parseValue(rawValue interface{}, argDef interface{}) interface{} {
+	value := p.coerceValue(rawValue, argDef)
+	if argDefMap, ok := argDef.(map[string]interface{}); ok {
+		return p.transformValue(value, argDefMap)
+	}
+	return value
+}
+
+// coerceValue converts a raw value to the Go type of its argument definition
+func (p *ArgParser) coerceValue(rawValue interface{}, argDef interface{}) interface{} {
 	// Handle argument definition as map
 	if argDefMap, ok := argDef.(map[string]interface{}); ok {
-		argType, _ := argDefMap["type"].(string)
+		argType := argTypeString(argDefMap)
+		if strings.HasPrefix(argType, "[") {
+			// A non-null list ([T]!) coerces its items the same way as a nullable one
+			argType = strings.TrimSuffix(argType, "!")
+		}
 
 		switch {
 		case argType == "Object":
@@ -706,7 +1246,11 @@ func (p *ArgParser) parseValue(rawValue interface{}, argDef interface{}) interfa
 			return p.parseIntArray(rawValue)
 		case argType == "[Boolean]" || argType == "[Boolean!]":
 			return p.parseBooleanArray(rawValue)
-		case argType == "String" || argType == "String!":
+		case argType == "[Float]" || argType == "[Float!]":
+			return p.parseFloatArray(rawValue)
+		case argType == "[ID]" || argType == "[ID!]":
+			return p.parseStringArray(rawValue)
+		case argType == "String" || argType == "String!", argType == "ID" || argType == "ID!":
 			return p.parseString(rawValue)
 		case argType == "Int" || argType == "Int!":
 			return p.parseInt(rawValue)
@@ -715,6 +1259,10 @@ func (p *ArgParser) parseValue(rawValue interface{}, argDef interface{}) interfa
 		case argType == "Float" || argType == "Float!":
 			return p.parseFloat(rawValue)
 		default:
+			// Fall back to a registered object type when the arg type names one
+			if objectType, list, ok := lookupRegisteredObjectType(argType); ok {
+				return p.parseRegisteredValue(rawValue, objectType.TypeName, list, make(map[uintptr]bool))
+			}
 			return rawValue
 		}
 	}
@@ -722,84 +1270,217 @@ func (p *ArgParser) parseValue(rawValue interface{}, argDef interface{}) interfa
 	return rawValue
 }
 
-// parseObject converts raw object data to structured map
-func (p *ArgParser) parseObject(rawValue interface{}, argDef map[string]interface{}) map[string]interface{} {
-	if objMap, ok := rawValue.(map[string]interface{}); ok {
-		result := make(map[string]interface{})
-
-		// If properties are defined, validate and convert them
-		if properties, exists := argDef["properties"]; exists {
-			if propMap, ok := properties.(map[string]interface{}); ok {
-				for propName, propValue := range objMap {
-					if propDef, exists := propMap[propName]; exists {
-						result[propName] = p.parseValue(propValue, propDef)
-					} else {
-						result[propName] = propValue // Keep unknown properties as-is
-					}
-				}
-				return result
+// lookupRegisteredObjectType resolves an argument type such as "Address", "Address!"
+// or "[Address!]" to an object type registered with the current plugin
+func lookupRegisteredObjectType(argType string) (ObjectTypeDefinition, bool, bool) {
+	if currentPlugin == nil {
+		return ObjectTypeDefinition{}, false, false
+	}
+
+	typeName := strings.TrimSuffix(argType, "!")
+	list := strings.HasPrefix(typeName, "[") && strings.HasSuffix(typeName, "]")
+	if list {
+		typeName = strings.TrimSuffix(strings.TrimPrefix(typeName, "["), "]")
+		typeName = strings.TrimSuffix(typeName, "!")
+	}
+
+	objectType, exists := currentPlugin.GetObjectType(typeName)
+	return objectType, list, exists
+}
+
+// parseRegisteredValue coerces a raw value against a registered object type, optionally as a list.
+// The visited set tracks the maps already being parsed so cyclic input data cannot recurse forever.
+func (p *ArgParser) parseRegisteredValue(rawValue interface{}, typeName string, list bool, visited map[uintptr]bool) interface{} {
+	if !list {
+		return p.parseRegisteredObject(rawValue, typeName, visited)
+	}
+
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
+	}
+	result := make([]interface{}, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseRegisteredObject(item, typeName, visited)
+	}
+	return result
+}
+
+// parseRegisteredObject validates and coerces the fields of a raw object using a registered object type
+func (p *ArgParser) parseRegisteredObject(rawValue interface{}, typeName string, visited map[uintptr]bool) interface{} {
+	objMap, ok := rawValue.(map[string]interface{})
+	if !ok {
+		return rawValue
+	}
+
+	objectType, exists := currentPlugin.GetObjectType(typeName)
+	if !exists {
+		return objMap
+	}
+
+	// Guard against cyclic input maps (self-referential types are fine, cyclic data is not)
+	ptr := reflect.ValueOf(objMap).Pointer()
+	if visited[ptr] {
+		return objMap
+	}
+	visited[ptr] = true
+	defer delete(visited, ptr)
+
+	result := make(map[string]interface{}, len(objMap))
+	for fieldName, fieldValue := range objMap {
+		fieldDef, known := objectType.Fields[fieldName]
+		if !known || fieldValue == nil {
+			result[fieldName] = fieldValue // Keep unknown properties and nulls as-is
+			continue
+		}
+
+		if fieldDef.List {
+			arr := coerceListInput(fieldValue)
+			items := make([]interface{}, len(arr))
+			for i, item := range arr {
+				items[i] = p.parseObjectFieldValue(item, fieldDef.Type, visited)
 			}
+			result[fieldName] = items
+			continue
 		}
 
-		return objMap
+		result[fieldName] = p.parseObjectFieldValue(fieldValue, fieldDef.Type, visited)
 	}
 
-	if objMap, ok := rawValue.(map[string]interface{}); ok {
+	return result
+}
+
+// parseObjectFieldValue coerces a single (non-list) value of an object type field
+func (p *ArgParser) parseObjectFieldValue(rawValue interface{}, fieldType string, visited map[uintptr]bool) interface{} {
+	if rawValue == nil {
+		return nil
+	}
+
+	switch fieldType {
+	case "String", "ID", "DateTime":
+		return p.parseString(rawValue)
+	case "Int":
+		return p.parseInt(rawValue)
+	case "Boolean":
+		return p.parseBoolean(rawValue)
+	case "Float":
+		return p.parseFloat(rawValue)
+	default:
+		return p.parseRegisteredObject(rawValue, fieldType, visited)
+	}
+}
+
+// parseObject converts raw object data to structured map
+func (p *ArgParser) parseObject(rawValue interface{}, argDef map[string]interface{}) map[string]interface{} {
+	objMap, ok := rawValue.(map[string]interface{})
+	if !ok {
+		return make(map[string]interface{})
+	}
+
+	// Without a properties definition the object passes through unchanged
+	propMap, ok := argDef["properties"].(map[string]interface{})
+	if !ok {
 		return objMap
 	}
-	return make(map[string]interface{})
+
+	// Known properties are coerced; unknown ones are kept as-is unless the parser is strict
+	result := make(map[string]interface{}, len(objMap))
+	for propName, propValue := range objMap {
+		if propDef, known := propMap[propName]; known {
+			result[propName] = p.parseValue(propValue, propDef)
+		} else if !p.strict {
+			result[propName] = propValue
+		}
+	}
+	return result
 }
 
-// parseObjectArray converts raw array of objects
-func (p *ArgParser) parseObjectArray(rawValue interface{}, argDef map[string]interface{}) []interface{} {
+// coerceListInput applies GraphQL list input coercion: an array is used as-is, any other
+// non-null value becomes a one-element list, and null stays null (nil)
+func coerceListInput(rawValue interface{}) []interface{} {
+	if rawValue == nil {
+		return nil
+	}
 	if arr, ok := rawValue.([]interface{}); ok {
-		result := make([]interface{}, len(arr))
-		for i, item := range arr {
-			result[i] = p.parseObject(item, argDef)
+		return arr
+	}
+
+	// Typed slices, e.g. []string built by Go callers rather than decoded from structpb
+	val := reflect.ValueOf(rawValue)
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		arr := make([]interface{}, val.Len())
+		for i := range arr {
+			arr[i] = val.Index(i).Interface()
 		}
-		return result
+		return arr
 	}
 
 	return []interface{}{rawValue}
 }
 
+// parseObjectArray converts raw array of objects
+func (p *ArgParser) parseObjectArray(rawValue interface{}, argDef map[string]interface{}) []interface{} {
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
+	}
+	result := make([]interface{}, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseObject(item, argDef)
+	}
+	return result
+}
+
 // parseStringArray converts raw array to string array
 func (p *ArgParser) parseStringArray(rawValue interface{}) []string {
-	if arr, ok := rawValue.([]interface{}); ok {
-		result := make([]string, len(arr))
-		for i, item := range arr {
-			result[i] = p.parseString(item)
-		}
-		return result
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
 	}
-
-	return []string{p.parseString(rawValue)}
+	result := make([]string, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseString(item)
+	}
+	return result
 }
 
 // parseIntArray converts raw array to int array
 func (p *ArgParser) parseIntArray(rawValue interface{}) []int {
-	if arr, ok := rawValue.([]interface{}); ok {
-		result := make([]int, len(arr))
-		for i, item := range arr {
-			result[i] = p.parseInt(item)
-		}
-		return result
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
+	}
+	result := make([]int, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseInt(item)
 	}
+	return result
+}
 
-	return []int{p.parseInt(rawValue)}
+// parseFloatArray converts raw array to float array
+func (p *ArgParser) parseFloatArray(rawValue interface{}) []float64 {
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
+	}
+	result := make([]float64, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseFloat(item)
+	}
+	return result
 }
 
 // parseBooleanArray converts raw array to boolean array
 func (p *ArgParser) parseBooleanArray(rawValue interface{}) []bool {
-	if arr, ok := rawValue.([]interface{}); ok {
-		result := make([]bool, len(arr))
-		for i, item := range arr {
-			result[i] = p.parseBoolean(item)
-		}
-		return result
+	arr := coerceListInput(rawValue)
+	if arr == nil {
+		return nil
 	}
-
-	return []bool{p.parseBoolean(rawValue)}
+	result := make([]bool, len(arr))
+	for i, item := range arr {
+		result[i] = p.parseBoolean(item)
+	}
+	return result
 }
 
 // parseString safely converts value to string
@@ -867,224 +1548,141 @@ func ParseGraphQLArgs(field GraphQLField, rawArgs map[string]interface{}) map[st
 
 // GetStringArg safely extracts a string argument
 func GetStringArg(args map[string]interface{}, name string, defaultValue ...string) string {
-	if val, exists := args[name]; exists && val != nil {
-		if str, ok := val.(string); ok {
-			return str
-		}
-		// If it's not a string but exists and not nil, convert safely (avoiding "<nil>")
-		return fmt.Sprintf("%v", val)
-	}
-	if len(defaultValue) > 0 {
-		return defaultValue[0]
-	}
-	return ""
+	return GetArg(args, name, defaultValue...)
 }
 
 // GetIntArg safely extracts an int argument
 func GetIntArg(args map[string]interface{}, name string, defaultValue ...int) int {
-	if val, exists := args[name]; exists && val != nil {
-		switch v := val.(type) {
-		case int:
-			return v
-		case float64:
-			return int(v)
-		case int64:
-			return int(v)
-		case string:
-			if i, err := strconv.Atoi(v); err == nil {
-				return i
-			}
-		}
-	}
-	if len(defaultValue) > 0 {
-		return defaultValue[0]
-	}
-	return 0
+	return GetArg(args, name, defaultValue...)
 }
 
 // GetBoolArg safely extracts a boolean argument
 func GetBoolArg(args map[string]interface{}, name string, defaultValue ...bool) bool {
-	if val, exists := args[name]; exists && val != nil {
-		if b, ok := val.(bool); ok {
-			return b
-		}
-		// Handle string representations of booleans
-		if str, ok := val.(string); ok {
-			if b, err := strconv.ParseBool(str); err == nil {
-				return b
-			}
-		}
-	}
-	if len(defaultValue) > 0 {
-		return defaultValue[0]
-	}
-	return false
+	return GetArg(args, name, defaultValue...)
 }
 
 // GetFloatArg safely extracts a float argument
 func GetFloatArg(args map[string]interface{}, name string, defaultValue ...float64) float64 {
-	if val, exists := args[name]; exists && val != nil {
-		switch v := val.(type) {
-		case float64:
-			return v
-		case float32:
-			return float64(v)
-		case int:
-			return float64(v)
-		case int64:
-			return float64(v)
-		case string:
-			if f, err := strconv.ParseFloat(v, 64); err == nil {
-				return f
+	return GetArg(args, name, defaultValue...)
+}
+
+// GetNestedArg safely walks nested objects and lists, e.g. GetNestedArg(args, "user", "addresses", "0", "zip").
+// Numeric segments index into lists. It returns false if any level is missing or has the wrong type.
+func GetNestedArg(args map[string]interface{}, path ...string) (interface{}, bool) {
+	var current interface{} = args
+	for _, segment := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exists := node[segment]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		default:
+			index, err := strconv.Atoi(segment)
+			if err != nil || node == nil {
+				return nil, false
+			}
+			list := reflect.ValueOf(node)
+			if list.Kind() != reflect.Slice || index < 0 || index >= list.Len() {
+				return nil, false
 			}
+			current = list.Index(index).Interface()
 		}
 	}
-	if len(defaultValue) > 0 {
-		return defaultValue[0]
+	return current, current != nil
+}
+
+// GetNestedString safely extracts a nested string argument, returning defaultValue if the path doesn't resolve
+func GetNestedString(args map[string]interface{}, defaultValue string, path ...string) string {
+	value, ok := GetNestedArg(args, path...)
+	if !ok {
+		return defaultValue
 	}
-	return 0.0
+	if typed, ok := coerceArg[string](value); ok {
+		return typed
+	}
+	return defaultValue
 }
 
-// GetObjectArg safely extracts an object argument
-func GetObjectArg(args map[string]interface{}, name string) map[string]interface{} {
-	if val, exists := args[name]; exists && val != nil {
-		if obj, ok := val.(map[string]interface{}); ok {
-			return obj
-		}
+// GetNestedInt safely extracts a nested int argument, returning defaultValue if the path doesn't resolve
+func GetNestedInt(args map[string]interface{}, defaultValue int, path ...string) int {
+	value, ok := GetNestedArg(args, path...)
+	if !ok {
+		return defaultValue
+	}
+	if typed, ok := coerceArg[int](value); ok {
+		return typed
+	}
+	return defaultValue
+}
+
+// GetNestedBool safely extracts a nested boolean argument, returning defaultValue if the path doesn't resolve
+func GetNestedBool(args map[string]interface{}, defaultValue bool, path ...string) bool {
+	value, ok := GetNestedArg(args, path...)
+	if !ok {
+		return defaultValue
+	}
+	if typed, ok := coerceArg[bool](value); ok {
+		return typed
+	}
+	return defaultValue
+}
+
+// GetNestedFloat safely extracts a nested float argument, returning defaultValue if the path doesn't resolve
+func GetNestedFloat(args map[string]interface{}, defaultValue float64, path ...string) float64 {
+	value, ok := GetNestedArg(args, path...)
+	if !ok {
+		return defaultValue
+	}
+	if typed, ok := coerceArg[float64](value); ok {
+		return typed
+	}
+	return defaultValue
+}
+
+// GetNestedObject safely extracts a nested object argument, returning an empty map if the path doesn't resolve
+func GetNestedObject(args map[string]interface{}, path ...string) map[string]interface{} {
+	value, _ := GetNestedArg(args, path...)
+	if obj, ok := value.(map[string]interface{}); ok {
+		return obj
 	}
 	return make(map[string]interface{})
 }
 
+// GetObjectArg safely extracts an object argument
+func GetObjectArg(args map[string]interface{}, name string) map[string]interface{} {
+	return GetArg(args, name, make(map[string]interface{}))
+}
+
 // GetArrayArg safely extracts an array argument
 func GetArrayArg(args map[string]interface{}, name string) []interface{} {
-	if val, exists := args[name]; exists && val != nil {
-		if arr, ok := val.([]interface{}); ok {
-			return arr
-		}
-	}
-	return []interface{}{}
+	return GetArg(args, name, []interface{}{})
 }
 
 // GetArrayObjectArg safely extracts an array of objects argument and provides typed access to each object
 func GetArrayObjectArg(args map[string]interface{}, name string) []map[string]interface{} {
-	result := []map[string]interface{}{}
-	if val, exists := args[name]; exists && val != nil {
-		if arr, ok := val.([]interface{}); ok {
-			for _, item := range arr {
-				if obj, ok := item.(map[string]interface{}); ok {
-					result = append(result, obj)
-				}
-			}
-		}
-	}
-	return result
+	return GetArg(args, name, []map[string]interface{}{})
 }
 
 // GetStringArrayArg gets a string array argument value with proper type conversion
 func GetStringArrayArg(args map[string]interface{}, name string) []string {
-	if val, exists := args[name]; exists && val != nil {
-		// Handle []interface{} with string values
-		if arr, ok := val.([]interface{}); ok {
-			result := make([]string, len(arr))
-			for i, item := range arr {
-				if str, ok := item.(string); ok {
-					result[i] = str
-				} else {
-					// Convert to string if possible
-					result[i] = fmt.Sprintf("%v", item)
-				}
-			}
-			return result
-		}
-		// Handle direct []string
-		if arr, ok := val.([]string); ok {
-			return arr
-		}
-	}
-	return []string{}
+	return GetArg(args, name, []string{})
 }
 
 // GetIntArrayArg gets an int array argument value with proper type conversion
 func GetIntArrayArg(args map[string]interface{}, name string) []int {
-	if val, exists := args[name]; exists && val != nil {
-		// Handle []interface{} with numeric values
-		if arr, ok := val.([]interface{}); ok {
-			result := make([]int, 0, len(arr))
-			for _, item := range arr {
-				if intVal, ok := item.(int); ok {
-					result = append(result, intVal)
-				} else if floatVal, ok := item.(float64); ok {
-					result = append(result, int(floatVal))
-				} else if strVal, ok := item.(string); ok {
-					if intVal, err := strconv.Atoi(strVal); err == nil {
-						result = append(result, intVal)
-					}
-				}
-			}
-			return result
-		}
-		// Handle direct []int
-		if arr, ok := val.([]int); ok {
-			return arr
-		}
-	}
-	return []int{}
+	return GetArg(args, name, []int{})
 }
 
 // GetFloatArrayArg gets a float array argument value with proper type conversion
 func GetFloatArrayArg(args map[string]interface{}, name string) []float64 {
-	if val, exists := args[name]; exists && val != nil {
-		// Handle []interface{} with numeric values
-		if arr, ok := val.([]interface{}); ok {
-			result := make([]float64, 0, len(arr))
-			for _, item := range arr {
-				if floatVal, ok := item.(float64); ok {
-					result = append(result, floatVal)
-				} else if intVal, ok := item.(int); ok {
-					result = append(result, float64(intVal))
-				} else if strVal, ok := item.(string); ok {
-					if floatVal, err := strconv.ParseFloat(strVal, 64); err == nil {
-						result = append(result, floatVal)
-					}
-				}
-			}
-			return result
-		}
-		// Handle direct []float64
-		if arr, ok := val.([]float64); ok {
-			return arr
-		}
-	}
-	return []float64{}
+	return GetArg(args, name, []float64{})
 }
 
 // GetBoolArrayArg gets a bool array argument value with proper type conversion
 func GetBoolArrayArg(args map[string]interface{}, name string) []bool {
-	if val, exists := args[name]; exists && val != nil {
-		// Handle []interface{} with boolean values
-		if arr, ok := val.([]interface{}); ok {
-			result := make([]bool, 0, len(arr))
-			for _, item := range arr {
-				if boolVal, ok := item.(bool); ok {
-					result = append(result, boolVal)
-				} else if strVal, ok := item.(string); ok {
-					if boolVal, err := strconv.ParseBool(strVal); err == nil {
-						result = append(result, boolVal)
-					}
-				} else if intVal, ok := item.(int); ok {
-					result = append(result, intVal != 0)
-				} else if floatVal, ok := item.(float64); ok {
-					result = append(result, floatVal != 0)
-				}
-			}
-			return result
-		}
-		// Handle direct []bool
-		if arr, ok := val.([]bool); ok {
-			return arr
-		}
-	}
-	return []bool{}
+	return GetArg(args, name, []bool{})
 }
 
 // ParseArgsForResolver automatically parses arguments for the current resolver based on field definition
@@ -1173,11 +1771,48 @@ func GetTenantID(args map[string]interface{}) string {
 	return GetContextString(args, "tenant_id")
 }
 
+// GetRoles extracts the caller's roles from context data. The host may send them
+// as a list or as a comma-separated string.
+func GetRoles(args map[string]interface{}) []string {
+	var roles []string
+	switch v := args["context_roles"].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if role, ok := item.(string); ok && strings.TrimSpace(role) != "" {
+				roles = append(roles, strings.TrimSpace(role))
+			}
+		}
+	case []string:
+		for _, role := range v {
+			if strings.TrimSpace(role) != "" {
+				roles = append(roles, strings.TrimSpace(role))
+			}
+		}
+	case string:
+		for _, role := range strings.Split(v, ",") {
+			if strings.TrimSpace(role) != "" {
+				roles = append(roles, strings.TrimSpace(role))
+			}
+		}
+	}
+	return roles
+}
+
 // GetTenantIDFromContext extracts the tenant ID directly from context
 func GetTenantIDFromContext(ctx context.Context) string {
 	return GetContextFromContext(ctx, "tenant_id")
 }
 
+// GetPluginNameFromContext extracts the executing plugin's name directly from context
+func GetPluginNameFromContext(ctx context.Context) string {
+	return GetContextFromContext(ctx, "plugin_name")
+}
+
+// GetPluginVersionFromContext extracts the executing plugin's version directly from context
+func GetPluginVersionFromContext(ctx context.Context) string {
+	return GetContextFromContext(ctx, "plugin_version")
+}
+
 // GetAllContextData extracts all context data from args
 func GetAllContextData(args map[string]interface{}) map[string]interface{} {
 	contextData := make(map[string]interface{})
@@ -1228,7 +1863,8 @@ func createNonNullType(ofType GraphQLTypeDefinition) GraphQLTypeDefinition {
 	}
 }
 
-// convertObjectFieldsToGraphQLFields converts ObjectFieldDef map to GraphQL field definitions
+// convertObjectFieldsToGraphQLFields converts ObjectFieldDef map to GraphQL field definitions.
+// Object fields become references by type name so circular object types never recurse.
 func convertObjectFieldsToGraphQLFields(fields map[string]ObjectFieldDef) map[string]interface{} {
 	result := make(map[string]interface{})
 
@@ -1260,10 +1896,15 @@ func convertObjectFieldsToGraphQLFields(fields map[string]ObjectFieldDef) map[st
 			fieldType = createNonNullType(fieldType)
 		}
 
-		result[fieldName] = map[string]interface{}{
+		graphQLField := map[string]interface{}{
 			"type":        fieldType,
 			"description": fieldDef.Description,
 		}
+		if fieldDef.IsDeprecated {
+			graphQLField["deprecated"] = true
+			graphQLField["deprecationReason"] = fieldDef.DeprecationReason
+		}
+		result[fieldName] = graphQLField
 	}
 
 	return result
@@ -1272,7 +1913,7 @@ func convertObjectFieldsToGraphQLFields(fields map[string]ObjectFieldDef) map[st
 // isScalarType checks if a type is a GraphQL scalar type
 func isScalarType(typeName string) bool {
 	switch typeName {
-	case "String", "Int", "Boolean", "Float", "ID":
+	case "String", "Int", "Boolean", "Float", "ID", "DateTime":
 		return true
 	default:
 		return false
@@ -1414,6 +2055,58 @@ func GetQueryParamInt(args map[string]interface{}, paramName string, defaultValu
 	return 0
 }
 
+// GetQueryParamSlice extracts a multi-valued query parameter from REST API arguments.
+// The engine sends a repeated key (?id=1&id=2) as an array under "query_<name>" and a
+// single occurrence as a plain string; both forms are accepted, and every string value is
+// additionally split on commas so ?id=1,2 yields the same result. Empty items are dropped.
+func GetQueryParamSlice(args map[string]interface{}, paramName string) []string {
+	val, exists := args["query_"+paramName]
+	if !exists {
+		val, exists = args[paramName]
+	}
+	if !exists || val == nil {
+		return nil
+	}
+
+	var raw []string
+	switch v := val.(type) {
+	case string:
+		raw = []string{v}
+	case []string:
+		raw = v
+	case []interface{}:
+		for _, item := range v {
+			if item != nil {
+				raw = append(raw, fmt.Sprintf("%v", item))
+			}
+		}
+	default:
+		raw = []string{fmt.Sprintf("%v", v)}
+	}
+
+	var values []string
+	for _, item := range raw {
+		for _, part := range strings.Split(item, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+// GetQueryParamIntSlice extracts a multi-valued integer query parameter from REST API arguments.
+// See GetQueryParamSlice for the accepted encodings; values that are not integers are skipped.
+func GetQueryParamIntSlice(args map[string]interface{}, paramName string) []int {
+	var values []int
+	for _, str := range GetQueryParamSlice(args, paramName) {
+		if i, err := strconv.Atoi(str); err == nil {
+			values = append(values, i)
+		}
+	}
+	return values
+}
+
 // GetBodyParam extracts a parameter from the POST/PUT/PATCH request body
 // Body parameters may be sent with "body_" prefix by the engine
 func GetBodyParam(args map[string]interface{}, paramName string, defaultValue ...string) string {
@@ -1460,6 +2153,37 @@ func GetBodyParamArray(args map[string]interface{}, paramName string) []interfac
 	return GetArrayArg(args, paramName)
 }
 
+// GetRawBody returns the unparsed request body and its content type, for payloads that don't
+// fit the JSON argument map (XML, plain text, protobuf) or must be checked byte-for-byte, such as
+// signed webhooks. The engine forwards such a body as:
+//
+//	raw_body           the body as a string
+//	raw_body_encoding  "base64" when raw_body is base64-encoded binary data, otherwise absent
+//	content_type       the request Content-Type (or "content_type" / "http_content_type" context data)
+//
+// ok is false when the request carries no raw body.
+func GetRawBody(args map[string]interface{}) (body []byte, contentType string, ok bool) {
+	raw, exists := args["raw_body"].(string)
+	if !exists {
+		return nil, "", false
+	}
+
+	body = []byte(raw)
+	if encoding, _ := args["raw_body_encoding"].(string); strings.EqualFold(encoding, "base64") {
+		decoded, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			return nil, "", false
+		}
+		body = decoded
+	}
+
+	contentType, _ = args["content_type"].(string)
+	if contentType == "" {
+		contentType = GetContextString(args, "content_type", GetContextString(args, "http_content_type"))
+	}
+	return body, contentType, true
+}
+
 // ParseRESTArgs provides a unified way to parse REST API arguments
 // It returns a map with categorized parameters for easier access
 func ParseRESTArgs(args map[string]interface{}) map[string]interface{} {
@@ -1509,9 +2233,11 @@ func ParseRESTArgs(args map[string]interface{}) map[string]interface{} {
 	return result
 }
 
-// LogRESTArgs logs REST API arguments in a structured way for debugging
+// LogRESTArgs logs REST API arguments in a structured way for debugging.
+// Values of redacted keys (see SetRedactedKeys) are logged as "***".
 func LogRESTArgs(functionName string, args map[string]interface{}) {
 	log.Printf("🌐 [REST-API] %s called with args:", functionName)
+	args = redactArgs(args, currentRedactionSet())
 
 	// Parse args to show them categorized
 	parsed := ParseRESTArgs(args)
@@ -1536,6 +2262,15 @@ func LogRESTArgs(functionName string, args map[string]interface{}) {
 	log.Printf("  🔧 Raw Arguments: %+v", args)
 }
 
+// RequireAuthenticated returns an UnauthorizedError when the request carries no user context.
+// Use it inside handlers as a defense-in-depth check for endpoints marked with RequireAuth.
+func RequireAuthenticated(args map[string]interface{}) error {
+	if GetUserID(args) == "" {
+		return UnauthorizedError("Authentication required")
+	}
+	return nil
+}
+
 // GetRESTEndpointInfo extracts information about the current REST endpoint
 // from the context or arguments if available
 func GetRESTEndpointInfo(args map[string]interface{}) map[string]interface{} {
@@ -1763,8 +2498,7 @@ func HandleErrorAndReturn(err error, defaultMessage string) (interface{}, error)
 	}
 
 	// If it's a coded error, convert it to GraphQL error
-	if IsCodedError(err) {
-		codedErr := err.(*CodedError)
+	if codedErr := GetCodedError(err); codedErr != nil {
 		extensions := map[string]interface{}{
 			"code":           "HTTP_ERROR",
 			"httpStatusCode": codedErr.Code,
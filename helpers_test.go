@@ -0,0 +1,224 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPaginatedResponseTypeSerialization(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	NewObjectType("User", "A user").AddIDField("id", "User ID", false).Build()
+
+	tests := []struct {
+		name      string
+		build     func() ObjectTypeDefinition
+		typeName  string
+		itemsType string
+		hasPaging bool
+	}{
+		{"legacy", func() ObjectTypeDefinition { return PaginatedResponseType("User") }, "PaginatedResponse", "[String]!", false},
+		{"typed", func() ObjectTypeDefinition { return TypedPaginatedResponseType("User") }, "UserPaginatedResponse", "[User!]!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := tt.build()
+			if def.TypeName != tt.typeName {
+				t.Fatalf("TypeName = %q, want %q", def.TypeName, tt.typeName)
+			}
+
+			serialized := p.impl.serializeObjectTypeDefinition(def)
+			fields := serialized["fields"].(map[string]interface{})
+			items := fields["items"].(map[string]interface{})
+			if got := typeDefinitionString(items["type"]); got != tt.itemsType {
+				t.Errorf("items type = %q, want %q", got, tt.itemsType)
+			}
+			if _, ok := fields["pagination"]; ok != tt.hasPaging {
+				t.Errorf("pagination field present = %v, want %v", ok, tt.hasPaging)
+			}
+		})
+	}
+}
+
+func TestListConstraintViolationCoercesScalars(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     map[string]interface{}
+		value   interface{}
+		wantErr bool
+	}{
+		{"scalar within limits", ListArgOf("String", "tags", MinItems(1), MaxItems(2)), "a", false},
+		{"scalar below minimum", ListArgOf("String", "tags", MinItems(2)), "a", true},
+		{"scalar above maximum", ListArgOf("String", "tags", MaxItems(0)), "a", true},
+		{"typed slice above maximum", ListArgOf("String", "tags", MaxItems(1)), []string{"a", "b"}, true},
+		{"null scalar with non-null items", ListArgOf("String", "tags", NonNullItems()), []interface{}{nil}, true},
+		{"missing value", ListArgOf("String", "tags", MinItems(1)), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := listConstraintViolation(tt.value, tt.arg)
+			if (message != "") != tt.wantErr {
+				t.Errorf("listConstraintViolation() = %q, want error %v", message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArgParserParsesListElementTypes(t *testing.T) {
+	parser := NewArgParser(FieldWithArgs("String", "Lists", map[string]interface{}{
+		"strings":  ListArg("String", "Strings"),
+		"ids":      ListArg("ID", "IDs"),
+		"ints":     ListArg("Int", "Ints"),
+		"floats":   ListArg("Float", "Floats"),
+		"booleans": ListArg("Boolean", "Booleans"),
+		"objects": ArrayObjectArg("Objects", map[string]interface{}{
+			"count": IntProperty("Count"),
+		}),
+	}))
+
+	tests := []struct {
+		arg  string
+		raw  interface{}
+		want interface{}
+	}{
+		{"strings", []interface{}{"a", "b"}, []string{"a", "b"}},
+		{"strings", "single", []string{"single"}},
+		{"ids", []interface{}{"1", 2.0}, []string{"1", "2"}},
+		{"ints", []interface{}{1.0, "2"}, []int{1, 2}},
+		{"ints", 3.0, []int{3}},
+		{"floats", []interface{}{1.5, 2.0}, []float64{1.5, 2}},
+		{"booleans", []interface{}{true, "false"}, []bool{true, false}},
+		{"objects", []interface{}{map[string]interface{}{"count": 1.0}, map[string]interface{}{"count": "2"}},
+			[]interface{}{map[string]interface{}{"count": 1}, map[string]interface{}{"count": 2}}},
+		{"objects", map[string]interface{}{"count": 4.0}, []interface{}{map[string]interface{}{"count": 4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.arg, tt.raw), func(t *testing.T) {
+			got := parser.ParseArgs(map[string]interface{}{tt.arg: tt.raw})[tt.arg]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseArgs(%v) = %#v, want %#v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgParserStrictObjects(t *testing.T) {
+	field := FieldWithArgs("String", "Create", map[string]interface{}{
+		"input": ObjectArg("Input", map[string]interface{}{
+			"name":  StringProperty("Name"),
+			"count": IntProperty("Count"),
+		}),
+	})
+
+	tests := []struct {
+		name   string
+		strict bool
+		raw    map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{"lenient keeps extra", false, map[string]interface{}{"name": "a", "count": "2", "extra": true}, map[string]interface{}{"name": "a", "count": 2, "extra": true}},
+		{"strict drops extra", true, map[string]interface{}{"name": "a", "count": "2", "extra": true}, map[string]interface{}{"name": "a", "count": 2}},
+		{"lenient partial", false, map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "a"}},
+		{"strict partial", true, map[string]interface{}{"count": 3.0}, map[string]interface{}{"count": 3}},
+		{"strict only extra", true, map[string]interface{}{"extra": "x"}, map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewArgParser(field)
+			if tt.strict {
+				parser = parser.StrictObjects()
+			}
+			got := parser.ParseArgs(map[string]interface{}{"input": tt.raw})["input"]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseArgs() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgParserResultIsDetachedFromRawArgs(t *testing.T) {
+	parser := NewArgParser(FieldWithArgs("String", "Update", map[string]interface{}{
+		"input": ObjectArg("Input", nil),
+		"tags":  Arg("JSON", "Free-form tags"),
+	}))
+
+	raw := map[string]interface{}{
+		"input": map[string]interface{}{"name": "a", "nested": map[string]interface{}{"x": 1.0}},
+		"tags":  []interface{}{"one", map[string]interface{}{"k": "v"}},
+	}
+
+	parsed := parser.ParseArgs(raw)
+	input := parsed["input"].(map[string]interface{})
+	input["name"] = "changed"
+	input["nested"].(map[string]interface{})["x"] = 2.0
+	tags := parsed["tags"].([]interface{})
+	tags[0] = "changed"
+	tags[1].(map[string]interface{})["k"] = "changed"
+
+	want := map[string]interface{}{
+		"input": map[string]interface{}{"name": "a", "nested": map[string]interface{}{"x": 1.0}},
+		"tags":  []interface{}{"one", map[string]interface{}{"k": "v"}},
+	}
+	if !reflect.DeepEqual(raw, want) {
+		t.Errorf("raw args mutated through the parsed result: %#v", raw)
+	}
+}
+
+func TestArgHelpersKeepTypeString(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      map[string]interface{}
+		typeName string
+	}{
+		{"Arg", Arg("String", "Name"), "String"},
+		{"NonNullArg", NonNullArg("ID", "ID"), "ID!"},
+		{"ListArg", ListArg("Int", "Counts"), "[Int]"},
+		{"ListArgOf non-null items", ListArgOf("String", "Tags", NonNullItems()), "[String!]"},
+		{"ObjectArg", ObjectArg("Input", nil), "Object"},
+		{"ArrayObjectArg", ArrayObjectArg("Inputs", nil), "[Object]"},
+		{"TypedArg", TypedArg(createNonNullType(createListType(parseArgTypeString("User"))), "Users"), "[User]!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typeName, ok := tt.arg["type"].(string)
+			if !ok || typeName != tt.typeName {
+				t.Fatalf(`arg["type"] = %#v, want %q`, tt.arg["type"], tt.typeName)
+			}
+			typeDef, ok := tt.arg["typeDef"].(GraphQLTypeDefinition)
+			if !ok || typeDefinitionString(typeDef) != tt.typeName {
+				t.Errorf(`arg["typeDef"] = %#v, want the structured form of %q`, tt.arg["typeDef"], tt.typeName)
+			}
+		})
+	}
+}
+
+func TestArgTypeStringAcceptsStructuredType(t *testing.T) {
+	structured := map[string]interface{}{"type": parseArgTypeString("[String!]!")}
+	if got := argTypeString(structured); got != "[String!]!" {
+		t.Errorf("argTypeString() = %q, want %q", got, "[String!]!")
+	}
+	typeDefOnly := map[string]interface{}{"typeDef": parseArgTypeString("Int!")}
+	if got := argTypeString(typeDefOnly); got != "Int!" {
+		t.Errorf("argTypeString() = %q, want %q", got, "Int!")
+	}
+}
+
+func TestLegacyArgsOmitTypeDef(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	serialized := p.impl.serializeGraphQLField(FieldWithArgs("String", "Search", map[string]interface{}{
+		"term": NonNullArg("String", "Search term"),
+	}))
+
+	term := serialized["args"].(map[string]interface{})["term"].(map[string]interface{})
+	if term["type"] != "String!" {
+		t.Errorf(`legacy arg type = %#v, want "String!"`, term["type"])
+	}
+	if _, exists := term["typeDef"]; exists {
+		t.Errorf("legacy arg carries typeDef: %v", term)
+	}
+}
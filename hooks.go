@@ -0,0 +1,56 @@
+package sdk
+
+import "context"
+
+// BeforeExecuteFunc runs before every handler invocation and may return an enriched context
+// that is passed on to the handler and to the AfterExecute hooks
+type BeforeExecuteFunc func(ctx context.Context, info RequestInfo) context.Context
+
+// AfterExecuteFunc runs after every handler invocation with the handler's result and error
+type AfterExecuteFunc func(ctx context.Context, info RequestInfo, result interface{}, err error)
+
+// BeforeExecute registers a hook run for every query, mutation, REST and function call
+// before its handler, in registration order. Returning nil keeps the incoming context.
+func (p *Plugin) BeforeExecute(hook BeforeExecuteFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.beforeHooks = append(p.beforeHooks, hook)
+}
+
+// AfterExecute registers a hook run for every query, mutation, REST and function call once
+// its handler has returned, in registration order. It also runs when the handler errors or
+// panics; a panic is reported as err and then re-raised.
+func (p *Plugin) AfterExecute(hook AfterExecuteFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.afterHooks = append(p.afterHooks, hook)
+}
+
+// runBeforeExecute runs the BeforeExecute hooks and returns the resulting context
+func (p *Plugin) runBeforeExecute(ctx context.Context) context.Context {
+	p.mu.RLock()
+	hooks := p.beforeHooks
+	p.mu.RUnlock()
+
+	info, _ := GetRequestInfo(ctx)
+	for _, hook := range hooks {
+		if next := hook(ctx, info); next != nil {
+			ctx = next
+		}
+	}
+	return ctx
+}
+
+// runAfterExecute runs the AfterExecute hooks
+func (p *Plugin) runAfterExecute(ctx context.Context, result interface{}, err error) {
+	p.mu.RLock()
+	hooks := p.afterHooks
+	p.mu.RUnlock()
+
+	info, _ := GetRequestInfo(ctx)
+	for _, hook := range hooks {
+		hook(ctx, info, result, err)
+	}
+}
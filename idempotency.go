@@ -0,0 +1,185 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+// IdempotencyStore caches the JSON-encoded result of a mutation per idempotency key.
+// Implement it on top of Redis or similar to share replays across plugin instances.
+type IdempotencyStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, result []byte, ttl time.Duration)
+}
+
+// SetIdempotency enables replaying cached results for GraphQL mutations and non-GET REST
+// requests that carry an idempotency key. Successful results are kept in store for ttl.
+// Pass a nil store to disable it.
+func (p *Plugin) SetIdempotency(store IdempotencyStore, ttl time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idempotencyStore = store
+	p.idempotencyTTL = ttl
+}
+
+// GetIdempotencyKey returns the Idempotency-Key the host passed in the request context
+func GetIdempotencyKey(args map[string]interface{}) (string, bool) {
+	key := GetContextString(args, "idempotency_key", GetContextString(args, "http_idempotency_key"))
+	return key, key != ""
+}
+
+// idempotentCall is an execution in progress for an idempotency key. Requests arriving with the
+// same key wait on done and share its outcome instead of running the handler again.
+type idempotentCall struct {
+	done   chan struct{}
+	result interface{}
+	err    error
+}
+
+// runIdempotent runs a mutation, or replays its cached result when the idempotency key was seen
+// before. Keys are scoped by function, tenant and user so callers can't replay each other's results.
+// A request whose key is still being executed, typically a host retry while the first attempt is
+// running, waits for that attempt and returns its result. This deduplication is per process;
+// instances sharing a store can still run a key concurrently.
+func (p *Plugin) runIdempotent(req *protobuff.ExecuteRequest, args map[string]interface{}, run func() (interface{}, error)) (interface{}, error) {
+	p.mu.RLock()
+	store, ttl := p.idempotencyStore, p.idempotencyTTL
+	p.mu.RUnlock()
+
+	if store == nil || !isWriteRequest(req) {
+		return run()
+	}
+	key, ok := GetIdempotencyKey(args)
+	if !ok {
+		return run()
+	}
+	storeKey := req.FunctionName + ":" + GetTenantID(args) + ":" + GetUserID(args) + ":" + key
+
+	if result, replayed := replayIdempotent(store, storeKey); replayed {
+		return result, nil
+	}
+
+	p.idempotencyMu.Lock()
+	if call, running := p.idempotencyInFlight[storeKey]; running {
+		p.idempotencyMu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	// A call for this key may have finished between the lookup above and taking the lock;
+	// it stored its result before leaving the in-flight set, so check the store again
+	if result, replayed := replayIdempotent(store, storeKey); replayed {
+		p.idempotencyMu.Unlock()
+		return result, nil
+	}
+	if p.idempotencyInFlight == nil {
+		p.idempotencyInFlight = make(map[string]*idempotentCall)
+	}
+	call := &idempotentCall{
+		done: make(chan struct{}),
+		err:  fmt.Errorf("request with idempotency key '%s' did not complete", key),
+	}
+	p.idempotencyInFlight[storeKey] = call
+	p.idempotencyMu.Unlock()
+
+	// Release waiters even if run panics; they then see the default error
+	defer func() {
+		p.idempotencyMu.Lock()
+		delete(p.idempotencyInFlight, storeKey)
+		p.idempotencyMu.Unlock()
+		close(call.done)
+	}()
+
+	result, err := run()
+	call.result, call.err = result, err
+	if err != nil {
+		return result, err
+	}
+
+	// Binary results don't survive a JSON round-trip, so they are never replayed.
+	// The result is stored before the key leaves the in-flight set, so later requests replay it.
+	if _, isBinary := asBinaryResult(result); !isBinary {
+		if raw, marshalErr := json.Marshal(result); marshalErr == nil {
+			store.Set(storeKey, raw, ttl)
+		}
+	}
+	return result, nil
+}
+
+// replayIdempotent returns the cached result for storeKey, if the store has a decodable one
+func replayIdempotent(store IdempotencyStore, storeKey string) (interface{}, bool) {
+	cached, exists := store.Get(storeKey)
+	if !exists {
+		return nil, false
+	}
+	var result interface{}
+	if err := json.Unmarshal(cached, &result); err != nil {
+		return nil, false
+	}
+	return result, true
+}
+
+// isWriteRequest reports whether a request mutates state: a GraphQL mutation or a non-GET REST request
+func isWriteRequest(req *protobuff.ExecuteRequest) bool {
+	switch req.FunctionType {
+	case "graphql_mutation":
+		return true
+	case "rest_api":
+		switch restRequestMethod(req.FunctionName) {
+		case "GET", "HEAD", "OPTIONS":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+// idempotencyEntry is a cached result and its expiry
+type idempotencyEntry struct {
+	result    []byte
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-process idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: make(map[string]idempotencyEntry)}
+}
+
+// Get returns the cached result for key if it hasn't expired
+func (s *MemoryIdempotencyStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// Set caches result under key for ttl, dropping expired entries along the way
+func (s *MemoryIdempotencyStore) Set(key string, result []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = idempotencyEntry{result: result, expiresAt: now.Add(ttl)}
+}
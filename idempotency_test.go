@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// staleReadStore blocks the first Get until release is closed and then reports a miss,
+// as a store read that raced with another request's Set would
+type staleReadStore struct {
+	*MemoryIdempotencyStore
+	gets    atomic.Int32
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *staleReadStore) Get(key string) ([]byte, bool) {
+	if s.gets.Add(1) == 1 {
+		close(s.entered)
+		<-s.release
+		return nil, false
+	}
+	return s.MemoryIdempotencyStore.Get(key)
+}
+
+// registerChargeMutation registers a mutation that counts how often its resolver runs
+func registerChargeMutation(calls *atomic.Int32) *Plugin {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterMutation("charge", Field("String", "Charge a card"), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		calls.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return "charged", nil
+	})
+	return p
+}
+
+func executeCharge(t *testing.T, p *Plugin) {
+	t.Helper()
+	args, err := structpb.NewStruct(map[string]interface{}{"context_idempotency_key": "order-1"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{
+		FunctionType: "graphql_mutation",
+		FunctionName: "charge",
+		Args:         args,
+	})
+	if err != nil || !resp.Success {
+		t.Errorf("Execute() = %v, %v", resp, err)
+	}
+}
+
+func TestIdempotentConcurrentRequestsRunOnce(t *testing.T) {
+	var calls atomic.Int32
+	p := registerChargeMutation(&calls)
+	p.SetIdempotency(NewMemoryIdempotencyStore(), time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			executeCharge(t, p)
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler ran %d times, want 1", got)
+	}
+}
+
+func TestIdempotentRequestRechecksStoreAfterStaleRead(t *testing.T) {
+	var calls atomic.Int32
+	p := registerChargeMutation(&calls)
+	store := &staleReadStore{
+		MemoryIdempotencyStore: NewMemoryIdempotencyStore(),
+		entered:                make(chan struct{}),
+		release:                make(chan struct{}),
+	}
+	p.SetIdempotency(store, time.Minute)
+
+	// The retry misses the store, then the first attempt completes before it takes the lock
+	retried := make(chan struct{})
+	go func() {
+		defer close(retried)
+		executeCharge(t, p)
+	}()
+	<-store.entered
+	executeCharge(t, p)
+	close(store.release)
+	<-retried
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler ran %d times, want 1", got)
+	}
+}
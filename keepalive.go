@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// KeepaliveConfig configures gRPC keepalive pings so idle connections survive proxies
+type KeepaliveConfig struct {
+	Time    time.Duration // Idle time before the server pings the host (default 30s)
+	Timeout time.Duration // How long to wait for a ping ack before closing the connection (default 10s)
+	MinTime time.Duration // Minimum interval the host may ping at before being disconnected (default 10s)
+}
+
+// Keepalive defaults used for zero KeepaliveConfig fields
+const (
+	DefaultKeepaliveTime    = 30 * time.Second
+	DefaultKeepaliveTimeout = 10 * time.Second
+	DefaultKeepaliveMinTime = 10 * time.Second
+)
+
+// SetKeepalive enables gRPC keepalive for Serve, ServeTCP and ServeWithTLS. Zero fields use the
+// defaults. MinTime must not exceed Time, otherwise hosts configured with the same interval would
+// have their pings rejected by the enforcement policy and their connections reset.
+func (p *Plugin) SetKeepalive(config KeepaliveConfig) error {
+	if config.Time == 0 {
+		config.Time = DefaultKeepaliveTime
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultKeepaliveTimeout
+	}
+	if config.MinTime == 0 {
+		config.MinTime = DefaultKeepaliveMinTime
+	}
+
+	if config.Time < time.Second {
+		return fmt.Errorf("keepalive time must be at least 1s, got %s", config.Time)
+	}
+	if config.Timeout < 0 || config.MinTime < 0 {
+		return fmt.Errorf("keepalive timeout and min time must not be negative")
+	}
+	if config.MinTime > config.Time {
+		return fmt.Errorf("keepalive min time %s exceeds keepalive time %s", config.MinTime, config.Time)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keepalive = &config
+	return nil
+}
+
+// keepaliveServerOptions returns the gRPC server options for the configured keepalive, if any
+func (p *Plugin) keepaliveServerOptions() []grpc.ServerOption {
+	p.mu.RLock()
+	config := p.keepalive
+	p.mu.RUnlock()
+
+	if config == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    config.Time,
+			Timeout: config.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.MinTime,
+			PermitWithoutStream: true,
+		}),
+	}
+}
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetLocale returns the caller's preferred locale: the host's "locale" context value, or
+// else the highest-weighted tag of the Accept-Language header (e.g. "fr-CH"). It is empty
+// when the caller expressed no preference.
+func GetLocale(args map[string]interface{}) string {
+	if locale := GetContextString(args, "locale"); locale != "" {
+		return locale
+	}
+	header := GetContextString(args, "accept_language", GetContextString(args, "http_accept_language"))
+	return preferredLanguage(header)
+}
+
+// preferredLanguage picks the tag with the highest q-value from an Accept-Language header,
+// keeping header order between equal weights
+func preferredLanguage(header string) string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			tags = append(tags, weightedTag{tag: tag, quality: quality})
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+	return tags[0].tag
+}
+
+// SetMessageCatalog installs translations keyed by locale (e.g. "fr" or "pt-BR") and then by
+// message key. Error messages returned by handlers are treated as keys: when the catalog has
+// an entry for the caller's locale (see GetLocale), or its base language, the CodedError or
+// GraphQLError message is replaced with the translation before it is sent to the host.
+func (p *Plugin) SetMessageCatalog(catalog map[string]map[string]string) {
+	normalized := make(map[string]map[string]string, len(catalog))
+	for locale, messages := range catalog {
+		normalized[strings.ToLower(locale)] = messages
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messageCatalog = normalized
+}
+
+// Localize returns the translation of key for the caller's locale from the current plugin's
+// message catalog, or key itself when there is none
+func Localize(args map[string]interface{}, key string) string {
+	if currentPlugin == nil {
+		return key
+	}
+	if message, ok := currentPlugin.lookupMessage(GetLocale(args), key); ok {
+		return message
+	}
+	return key
+}
+
+// lookupMessage finds key in the catalog for locale, falling back from "fr-CH" to "fr"
+func (p *Plugin) lookupMessage(locale, key string) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.messageCatalog) == 0 {
+		return "", false
+	}
+
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	for {
+		if message, ok := p.messageCatalog[locale][key]; ok {
+			return message, true
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return "", false
+		}
+		locale = locale[:i]
+	}
+}
+
+// localizeError returns a copy of a CodedError or GraphQLError with its message translated
+// for locale; other errors, and messages without a translation, are returned unchanged
+func (p *Plugin) localizeError(err error, locale string) error {
+	switch e := err.(type) {
+	case *CodedError:
+		if message, ok := p.lookupMessage(locale, e.Message); ok {
+			localized := *e
+			localized.Message = message
+			return &localized
+		}
+	case *GraphQLError:
+		if message, ok := p.lookupMessage(locale, e.Message); ok {
+			localized := *e
+			localized.Message = message
+			return &localized
+		}
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// SetLogger replaces the logger used by the plugin server and returned by LoggerFromContext
+func (p *Plugin) SetLogger(logger hclog.Logger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.logger = logger
+}
+
+// Logger returns the logger set with SetLogger, or an info-level stderr logger named after the plugin
+func (p *Plugin) Logger() hclog.Logger {
+	p.mu.RLock()
+	logger := p.logger
+	p.mu.RUnlock()
+
+	if logger != nil {
+		return logger
+	}
+	return hclog.New(&hclog.LoggerOptions{
+		Name:   p.name,
+		Output: os.Stderr,
+		Level:  hclog.Info,
+	})
+}
+
+// LoggerFromContext returns the plugin logger tagged with the request ID, function name,
+// user ID and tenant ID of the request being executed, so resolver logs correlate
+func LoggerFromContext(ctx context.Context) hclog.Logger {
+	var logger hclog.Logger
+	if currentPlugin != nil {
+		logger = currentPlugin.Logger()
+	} else {
+		logger = hclog.New(&hclog.LoggerOptions{Output: os.Stderr, Level: hclog.Info})
+	}
+
+	var fields []interface{}
+	if info, ok := GetRequestInfo(ctx); ok {
+		fields = append(fields, "request_id", info.RequestID, "function", info.FunctionName)
+	}
+	if userID := GetUserIDFromContext(ctx); userID != "" {
+		fields = append(fields, "user_id", userID)
+	}
+	if tenantID := GetTenantIDFromContext(ctx); tenantID != "" {
+		fields = append(fields, "tenant_id", tenantID)
+	}
+
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
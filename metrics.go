@@ -0,0 +1,159 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMetricsNamespace prefixes metric names unless SetMetricsNamespace overrides it
+const DefaultMetricsNamespace = "apito_plugin"
+
+// metricsRegistry collects per-function execution counts and latencies
+type metricsRegistry struct {
+	mu        sync.Mutex
+	namespace string
+	functions map[metricsKey]*functionMetrics
+}
+
+// metricsKey identifies a function by its type and name
+type metricsKey struct {
+	functionType string
+	functionName string
+}
+
+// functionMetrics are the counters for a single function
+type functionMetrics struct {
+	requests      uint64
+	errors        uint64
+	totalDuration time.Duration
+	maxDuration   time.Duration
+}
+
+// newMetricsRegistry creates an empty registry using the default namespace
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		namespace: DefaultMetricsNamespace,
+		functions: make(map[metricsKey]*functionMetrics),
+	}
+}
+
+// record counts one execution of a function
+func (m *metricsRegistry) record(functionType, functionName string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := metricsKey{functionType: functionType, functionName: functionName}
+	metrics, exists := m.functions[key]
+	if !exists {
+		metrics = &functionMetrics{}
+		m.functions[key] = metrics
+	}
+	metrics.requests++
+	if failed {
+		metrics.errors++
+	}
+	metrics.totalDuration += duration
+	if duration > metrics.maxDuration {
+		metrics.maxDuration = duration
+	}
+}
+
+// SetMetricsNamespace sets the prefix of the metric names rendered by the metrics endpoint
+func (p *Plugin) SetMetricsNamespace(namespace string) {
+	p.metrics.mu.Lock()
+	defer p.metrics.mu.Unlock()
+	p.metrics.namespace = namespace
+}
+
+// EnableMetricsEndpoint registers a GET REST endpoint (default "/metrics") rendering the
+// per-function request counts, errors and latencies plus process metrics in the Prometheus
+// text exposition format
+func (p *Plugin) EnableMetricsEndpoint(path string) {
+	if path == "" {
+		path = "/metrics"
+	}
+
+	p.RegisterRESTAPI(RESTEndpoint{
+		Method:      "GET",
+		Path:        path,
+		Description: "Plugin metrics in Prometheus format",
+	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return BinaryResult{
+			ContentType: "text/plain; version=0.0.4; charset=utf-8",
+			Data:        p.metrics.render(),
+		}, nil
+	})
+}
+
+// render writes the collected metrics in the Prometheus text exposition format
+func (m *metricsRegistry) render() []byte {
+	m.mu.Lock()
+	namespace := m.namespace
+	keys := make([]metricsKey, 0, len(m.functions))
+	snapshot := make(map[metricsKey]functionMetrics, len(m.functions))
+	for key, metrics := range m.functions {
+		keys = append(keys, key)
+		snapshot[key] = *metrics
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].functionType != keys[j].functionType {
+			return keys[i].functionType < keys[j].functionType
+		}
+		return keys[i].functionName < keys[j].functionName
+	})
+
+	name := func(metric string) string {
+		if namespace == "" {
+			return metric
+		}
+		return namespace + "_" + metric
+	}
+
+	var buf bytes.Buffer
+	writeFamily := func(metric, metricType, help string, value func(functionMetrics) string) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", name(metric), help, name(metric), metricType)
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "%s{function_type=\"%s\",function=\"%s\"} %s\n",
+				name(metric), escapeLabelValue(key.functionType), escapeLabelValue(key.functionName), value(snapshot[key]))
+		}
+	}
+
+	writeFamily("requests_total", "counter", "Total number of executions per function.", func(f functionMetrics) string {
+		return fmt.Sprintf("%d", f.requests)
+	})
+	writeFamily("errors_total", "counter", "Total number of failed executions per function.", func(f functionMetrics) string {
+		return fmt.Sprintf("%d", f.errors)
+	})
+	writeFamily("duration_seconds_sum", "counter", "Total execution time per function in seconds.", func(f functionMetrics) string {
+		return fmt.Sprintf("%g", f.totalDuration.Seconds())
+	})
+	writeFamily("duration_seconds_max", "gauge", "Slowest execution per function in seconds.", func(f functionMetrics) string {
+		return fmt.Sprintf("%g", f.maxDuration.Seconds())
+	})
+
+	// Process metrics, as reported by the health check
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	writeGauge := func(metric, help string, value interface{}) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name(metric), help, name(metric), name(metric), value)
+	}
+	writeGauge("goroutines", "Number of goroutines.", runtime.NumGoroutine())
+	writeGauge("memory_allocated_bytes", "Bytes of allocated heap objects.", memStats.Alloc)
+	writeGauge("memory_sys_bytes", "Bytes of memory obtained from the OS.", memStats.Sys)
+	writeGauge("gc_cycles", "Number of completed GC cycles.", memStats.NumGC)
+
+	return buf.Bytes()
+}
+
+// escapeLabelValue escapes a Prometheus label value
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
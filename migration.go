@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MigrationFromVersionEnv is the environment variable the host sets (via Init) to the
+// previously installed plugin version. MigrationRequest carries no version info, so when
+// it is unset every registered step up to the current version runs and steps must be idempotent.
+const MigrationFromVersionEnv = "APITO_PLUGIN_MIGRATE_FROM_VERSION"
+
+// MigrationFunc is the function signature for plugin migration steps
+type MigrationFunc func(ctx context.Context) error
+
+// migrationStep is a registered migration between two plugin versions
+type migrationStep struct {
+	fromVersion string
+	toVersion   string
+	fn          MigrationFunc
+}
+
+// name returns a human readable label for the step
+func (s migrationStep) name() string {
+	return s.fromVersion + "->" + s.toVersion
+}
+
+// RegisterMigration registers a migration step that upgrades plugin state from fromVersion to toVersion
+func (p *Plugin) RegisterMigration(fromVersion, toVersion string, fn MigrationFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.migrations = append(p.migrations, migrationStep{
+		fromVersion: fromVersion,
+		toVersion:   toVersion,
+		fn:          fn,
+	})
+}
+
+// pendingMigrations returns the steps to run, ordered by target version. Steps that target a
+// version newer than the plugin, or that start before the installed version, are skipped.
+func (p *Plugin) pendingMigrations(installedVersion string) []migrationStep {
+	steps := make([]migrationStep, 0, len(p.migrations))
+	for _, step := range p.migrations {
+		if compareVersions(step.toVersion, p.version) > 0 {
+			continue
+		}
+		if installedVersion != "" && compareVersions(step.fromVersion, installedVersion) < 0 {
+			continue
+		}
+		steps = append(steps, step)
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		return compareVersions(steps[i].toVersion, steps[j].toVersion) < 0
+	})
+	return steps
+}
+
+// compareVersions compares dotted version strings such as "1.2.10" and "v1.3.0",
+// returning -1, 0 or 1. Non-numeric parts are compared lexically.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart string
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		if aPart == "" {
+			aNum, aErr = 0, nil
+		}
+		if bPart == "" {
+			bNum, bErr = 0, nil
+		}
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aPart != bPart:
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package sdk
+
+import "strings"
+
+// SetTypeNamespace prefixes every object type name, and every reference to one, in the
+// serialized schema, e.g. "User" becomes "MyPlugin_User". This keeps common names like "User"
+// or "Error" from colliding with other plugins' types in the host's merged schema.
+// Type names used in Go code (builders, RegisterObjectType, GetObjectType) stay unprefixed.
+func (p *Plugin) SetTypeNamespace(prefix string) {
+	p.typeNamespace.Store(prefix)
+
+	p.mu.Lock()
+	p.schemaCache = nil
+	p.mu.Unlock()
+}
+
+// namespacedTypeName returns the schema name of a non-scalar type
+func (p *Plugin) namespacedTypeName(name string) string {
+	prefix, _ := p.typeNamespace.Load().(string)
+	if prefix == "" || name == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// namespacedTypeString rewrites the type named in a type string such as "[User!]!";
+// scalar and JSON passthrough types are left unchanged
+func (p *Plugin) namespacedTypeString(typeString string) string {
+	ref := objectTypeReference(typeString)
+	if ref == "" {
+		return typeString
+	}
+	start := strings.Index(typeString, ref)
+	return typeString[:start] + p.namespacedTypeName(ref) + typeString[start+len(ref):]
+}
+
+// namespacedInlineObjectType rewrites the type name and field type references of an inline
+// object type definition, as object-returning fields carry in their "objectType" argument
+func (p *Plugin) namespacedInlineObjectType(definition map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(definition))
+	for key, val := range definition {
+		result[key] = val
+	}
+	if typeName, ok := definition["typeName"].(string); ok {
+		result["typeName"] = p.namespacedTypeName(typeName)
+	}
+
+	if fields, ok := definition["fields"].(map[string]interface{}); ok {
+		namespacedFields := make(map[string]interface{}, len(fields))
+		for fieldName, value := range fields {
+			fieldDef, ok := value.(map[string]interface{})
+			fieldType, isString := fieldDef["type"].(string)
+			if !ok || !isString {
+				namespacedFields[fieldName] = value
+				continue
+			}
+			converted := make(map[string]interface{}, len(fieldDef))
+			for key, val := range fieldDef {
+				converted[key] = val
+			}
+			converted["type"] = p.namespacedTypeString(fieldType)
+			namespacedFields[fieldName] = converted
+		}
+		result["fields"] = namespacedFields
+	}
+	return result
+}
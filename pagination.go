@@ -0,0 +1,92 @@
+package sdk
+
+// Pagination bounds used by Paginate
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PaginationInfo mirrors the PaginationInfo object type
+type PaginationInfo struct {
+	Total       int  `json:"total"`
+	Limit       int  `json:"limit"`
+	Offset      int  `json:"offset"`
+	Page        int  `json:"page"`
+	TotalPages  int  `json:"totalPages"`
+	HasNext     bool `json:"hasNext"`
+	HasPrevious bool `json:"hasPrevious"`
+}
+
+// PagedResult is a page of items along with its pagination info
+type PagedResult[T any] struct {
+	Items      []T            `json:"items"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// ToMap converts the paged result to a map so it can be returned from resolvers and handlers
+func (r PagedResult[T]) ToMap() map[string]interface{} {
+	items := make([]interface{}, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = item
+	}
+
+	return map[string]interface{}{
+		"items": items,
+		"pagination": map[string]interface{}{
+			"total":       r.Pagination.Total,
+			"limit":       r.Pagination.Limit,
+			"offset":      r.Pagination.Offset,
+			"page":        r.Pagination.Page,
+			"totalPages":  r.Pagination.TotalPages,
+			"hasNext":     r.Pagination.HasNext,
+			"hasPrevious": r.Pagination.HasPrevious,
+		},
+	}
+}
+
+// Paginate slices items according to the limit, offset and page arguments.
+// The limit defaults to DefaultPageLimit and is capped at MaxPageLimit; offset takes
+// precedence over page, and page is 1-based.
+func Paginate[T any](args map[string]interface{}, items []T) PagedResult[T] {
+	limit := GetQueryParamInt(args, "limit", DefaultPageLimit)
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	offset := GetQueryParamInt(args, "offset", -1)
+	if offset < 0 {
+		page := GetQueryParamInt(args, "page", 1)
+		if page < 1 {
+			page = 1
+		}
+		offset = (page - 1) * limit
+	}
+
+	total := len(items)
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	totalPages := (total + limit - 1) / limit
+
+	return PagedResult[T]{
+		Items: items[start:end],
+		Pagination: PaginationInfo{
+			Total:       total,
+			Limit:       limit,
+			Offset:      offset,
+			Page:        offset/limit + 1,
+			TotalPages:  totalPages,
+			HasNext:     end < total,
+			HasPrevious: offset > 0,
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// PartialResult lets a GraphQL resolver return data together with field errors, e.g. a list
+// whose failed items are null in Data and described in Errors with their path:
+//
+//	return sdk.PartialResult{
+//		Data:   items,
+//		Errors: []sdk.GraphQLError{*sdk.NewGraphQLError("item unavailable", []interface{}{"orders", 3}, nil)},
+//	}, nil
+type PartialResult struct {
+	Data   interface{}
+	Errors []GraphQLError
+}
+
+// asPartialResult reports whether a resolver result is a PartialResult
+func asPartialResult(result interface{}) (PartialResult, bool) {
+	switch r := result.(type) {
+	case PartialResult:
+		return r, true
+	case *PartialResult:
+		if r != nil {
+			return *r, true
+		}
+	}
+	return PartialResult{}, false
+}
+
+// graphQLErrorObject converts a GraphQLError to the spec's error shape
+func graphQLErrorObject(gqlErr *GraphQLError) map[string]interface{} {
+	errorObj := map[string]interface{}{
+		"message": gqlErr.Message,
+	}
+
+	// Add extensions if they exist
+	if len(gqlErr.Extensions) > 0 {
+		errorObj["extensions"] = gqlErr.Extensions
+	}
+
+	// Add path if it exists and is not empty. List indices stay numbers as the
+	// spec requires; anything else is converted to a field name string.
+	if len(gqlErr.Path) > 0 {
+		pathSegments := make([]interface{}, len(gqlErr.Path))
+		for i, p := range gqlErr.Path {
+			switch segment := p.(type) {
+			case int, int32, int64:
+				pathSegments[i] = segment
+			case float64:
+				pathSegments[i] = int(segment)
+			default:
+				pathSegments[i] = fmt.Sprintf("%v", p)
+			}
+		}
+		errorObj["path"] = pathSegments
+	}
+
+	// Add locations if they exist and are not empty
+	if len(gqlErr.Locations) > 0 {
+		locations := make([]map[string]interface{}, len(gqlErr.Locations))
+		for i, loc := range gqlErr.Locations {
+			locations[i] = map[string]interface{}{
+				"line":   loc.Line,
+				"column": loc.Column,
+			}
+		}
+		errorObj["locations"] = locations
+	}
+
+	return errorObj
+}
+
+// partialResultResponse serializes a PartialResult in the GraphQL error format, with the
+// data set instead of nil so the host can return both
+func (impl *pluginImpl) partialResultResponse(ctx context.Context, req *protobuff.ExecuteRequest, partial PartialResult) *protobuff.ExecuteResponse {
+	errorObjs := make([]map[string]interface{}, len(partial.Errors))
+	for i := range partial.Errors {
+		errorObjs[i] = graphQLErrorObject(&partial.Errors[i])
+	}
+
+	// Serialize as JSON string for protobuf compatibility, like other GraphQL errors
+	errorsJSON, err := json.Marshal(errorObjs)
+	if err != nil {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to marshal partial result errors to JSON: %v", err),
+		}
+	}
+
+	partialResult := map[string]interface{}{
+		"graphql_errors":   string(errorsJSON),
+		"data":             toJSONCompatible(partial.Data),
+		"is_graphql_error": len(partial.Errors) > 0,
+		"partial":          true,
+		"request_id":       GetRequestID(ctx),
+	}
+
+	resultStruct, err := structpb.NewStruct(partialResult)
+	if err != nil {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to serialize partial result: %v", err),
+		}
+	}
+
+	anyResult, err := anypb.New(resultStruct)
+	if err != nil {
+		return &protobuff.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create partial result: %v", err),
+		}
+	}
+
+	return &protobuff.ExecuteResponse{
+		Success: true,
+		Message: "Partial result returned",
+		Result:  anyResult,
+	}
+}
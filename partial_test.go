@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestPartialResultWithOneFailingListItem(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterQuery("orders", Field("[String]", "Orders"), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		ids := []string{"a", "b", "c"}
+		items := make([]interface{}, len(ids))
+		var fieldErrors []GraphQLError
+		for i, id := range ids {
+			if id == "b" {
+				fieldErrors = append(fieldErrors, *NewGraphQLError("order unavailable", []interface{}{"orders", i}, nil))
+				continue
+			}
+			items[i] = id
+		}
+		return PartialResult{Data: items, Errors: fieldErrors}, nil
+	})
+
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "graphql_query", FunctionName: "orders"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Execute() = %v, %v", resp, err)
+	}
+
+	result := &structpb.Struct{}
+	if err := resp.Result.UnmarshalTo(result); err != nil {
+		t.Fatal(err)
+	}
+	fields := result.AsMap()
+	if fields["partial"] != true || fields["is_graphql_error"] != true {
+		t.Errorf("partial = %v, is_graphql_error = %v", fields["partial"], fields["is_graphql_error"])
+	}
+	if want := []interface{}{"a", nil, "c"}; !reflect.DeepEqual(fields["data"], want) {
+		t.Errorf("data = %#v, want %#v", fields["data"], want)
+	}
+
+	var gqlErrors []map[string]interface{}
+	if err := json.Unmarshal([]byte(fields["graphql_errors"].(string)), &gqlErrors); err != nil {
+		t.Fatal(err)
+	}
+	if len(gqlErrors) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(gqlErrors), gqlErrors)
+	}
+	if gqlErrors[0]["message"] != "order unavailable" {
+		t.Errorf("message = %v", gqlErrors[0]["message"])
+	}
+	if want := []interface{}{"orders", 1.0}; !reflect.DeepEqual(gqlErrors[0]["path"], want) {
+		t.Errorf("path = %#v, want %#v", gqlErrors[0]["path"], want)
+	}
+}
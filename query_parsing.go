@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortDirection is the direction of a sort field
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortField is a single field of a sort expression
+type SortField struct {
+	Field     string        `json:"field"`
+	Direction SortDirection `json:"direction"`
+}
+
+// FilterClause is a single clause of a filter expression
+type FilterClause struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Value    string   `json:"value"`
+	Values   []string `json:"values,omitempty"` // Set for the "in" operator
+}
+
+// filterOperators are the operators accepted by ParseFilter
+var filterOperators = map[string]bool{
+	"eq":       true,
+	"ne":       true,
+	"gt":       true,
+	"gte":      true,
+	"lt":       true,
+	"lte":      true,
+	"contains": true,
+	"in":       true,
+}
+
+// ParseSort parses a sort argument of the form "field[:asc|desc],field2[:asc|desc]".
+// The direction defaults to ascending. An absent argument yields no sort fields.
+func ParseSort(args map[string]interface{}, name string) ([]SortField, error) {
+	raw := strings.TrimSpace(GetQueryParam(args, name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, BadRequestError(fmt.Sprintf("Invalid %s expression", name), "empty sort field")
+		}
+
+		field, direction, _ := strings.Cut(part, ":")
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, BadRequestError(fmt.Sprintf("Invalid %s expression", name), fmt.Sprintf("missing field name in '%s'", part))
+		}
+
+		sortField := SortField{Field: field, Direction: SortAsc}
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			sortField.Direction = SortDesc
+		default:
+			return nil, BadRequestError(fmt.Sprintf("Invalid %s expression", name), fmt.Sprintf("unknown sort direction '%s'", direction))
+		}
+		fields = append(fields, sortField)
+	}
+
+	return fields, nil
+}
+
+// ParseFilter parses a filter argument of the form "field:operator:value,field2:operator:value".
+// Supported operators are eq, ne, gt, gte, lt, lte, contains and in; "in" takes values
+// separated by "|" (e.g. "status:in:active|pending"). Values may contain ":".
+// An absent argument yields no clauses.
+func ParseFilter(args map[string]interface{}, name string) ([]FilterClause, error) {
+	raw := strings.TrimSpace(GetQueryParam(args, name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	var clauses []FilterClause
+	for _, part := range strings.Split(raw, ",") {
+		segments := strings.SplitN(strings.TrimSpace(part), ":", 3)
+		if len(segments) != 3 || strings.TrimSpace(segments[0]) == "" {
+			return nil, BadRequestError(fmt.Sprintf("Invalid %s expression", name), fmt.Sprintf("expected field:operator:value, got '%s'", part))
+		}
+
+		operator := strings.ToLower(strings.TrimSpace(segments[1]))
+		if !filterOperators[operator] {
+			return nil, BadRequestError(fmt.Sprintf("Invalid %s expression", name), fmt.Sprintf("unknown filter operator '%s'", segments[1]))
+		}
+
+		clause := FilterClause{
+			Field:    strings.TrimSpace(segments[0]),
+			Operator: operator,
+			Value:    segments[2],
+		}
+		if operator == "in" {
+			clause.Values = strings.Split(segments[2], "|")
+		}
+		clauses = append(clauses, clause)
+	}
+
+	return clauses, nil
+}
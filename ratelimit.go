@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token-bucket limiter keyed by caller
+type rateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64 // tokens per second
+	buckets  map[string]*tokenBucket
+
+	// refillWindow is how long an empty bucket takes to refill. A bucket idle that long is
+	// full again, the same as a new one, so it is evicted on the next sweep.
+	refillWindow time.Duration
+	lastSweep    time.Time
+}
+
+// tokenBucket tracks the remaining tokens for a single caller
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// newRateLimiter creates a limiter allowing requestsPerMinute requests per caller
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	return &rateLimiter{
+		capacity:     float64(requestsPerMinute),
+		rate:         float64(requestsPerMinute) / 60.0,
+		buckets:      make(map[string]*tokenBucket),
+		refillWindow: time.Minute,
+		lastSweep:    time.Now(),
+	}
+}
+
+// allow consumes a token for the caller and reports whether the request may proceed
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.evictIdle(now)
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.capacity, lastFill: now}
+		l.buckets[key] = bucket
+	}
+
+	// Refill based on the time elapsed since the last request
+	bucket.tokens += now.Sub(bucket.lastFill).Seconds() * l.rate
+	if bucket.tokens > l.capacity {
+		bucket.tokens = l.capacity
+	}
+	bucket.lastFill = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// evictIdle drops buckets idle for longer than the refill window, at most once per window,
+// so per-user or per-IP keys don't grow the map without bound. Callers must hold l.mu.
+func (l *rateLimiter) evictIdle(now time.Time) {
+	if now.Sub(l.lastSweep) < l.refillWindow {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastFill) >= l.refillWindow {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// rateLimitKey identifies the caller from the tenant and user context data
+func rateLimitKey(args map[string]interface{}) string {
+	userID := GetUserID(args)
+	tenantID := GetTenantID(args)
+	if userID == "" && tenantID == "" {
+		return "anonymous"
+	}
+	return tenantID + ":" + userID
+}
+
+// RateLimitMiddleware wraps a resolver with local token-bucket rate limiting keyed by the
+// caller's tenant and user ID. It is a fallback for hosts that don't enforce the declared
+// limits and returns a 429 CodedError when a caller exceeds requestsPerMinute.
+// Use RESTHandlerFunc(...) or FunctionHandlerFunc(...) to apply it to other handler types.
+func RateLimitMiddleware(requestsPerMinute int, next ResolverFunc) ResolverFunc {
+	if requestsPerMinute <= 0 {
+		return next
+	}
+
+	limiter := newRateLimiter(requestsPerMinute)
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		if !limiter.allow(rateLimitKey(args)) {
+			return nil, TooManyRequestsError("Too many requests", "rate limit exceeded")
+		}
+		return next(ctx, args)
+	}
+}
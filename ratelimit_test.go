@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterEvictsIdleBuckets(t *testing.T) {
+	limiter := newRateLimiter(60)
+	for i := 0; i < 100; i++ {
+		limiter.allow(fmt.Sprintf("user-%d", i))
+	}
+	if len(limiter.buckets) != 100 {
+		t.Fatalf("buckets = %d, want 100", len(limiter.buckets))
+	}
+
+	// Age every bucket past the refill window, then let the next request trigger a sweep
+	past := time.Now().Add(-2 * limiter.refillWindow)
+	for _, bucket := range limiter.buckets {
+		bucket.lastFill = past
+	}
+	limiter.lastSweep = past
+
+	if !limiter.allow("user-new") {
+		t.Fatal("allow() = false for a new caller")
+	}
+	if len(limiter.buckets) != 1 {
+		t.Errorf("buckets after sweep = %d, want 1", len(limiter.buckets))
+	}
+}
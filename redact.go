@@ -0,0 +1,121 @@
+package sdk
+
+import "strings"
+
+// redactedValue replaces the value of a redacted key
+const redactedValue = "***"
+
+// DefaultRedactedKeys are masked in LogRESTArgs, debug output and audit records unless
+// SetRedactedKeys replaces them
+var DefaultRedactedKeys = []string{
+	"password",
+	"token",
+	"secret",
+	"authorization",
+	"api_key",
+	"apikey",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+}
+
+// argKeyPrefixes are the prefixes the host puts on REST and context arguments; they are
+// stripped before matching so "body_password" is redacted like "password"
+var argKeyPrefixes = []string{":", "path_", "query_", "body_", "context_", "header_", "http_"}
+
+// SetRedactedKeys replaces the argument keys whose values are masked as "***" in LogRESTArgs,
+// debug output and audit records. Keys match case-insensitively, at any depth and with or without
+// the REST argument prefixes. Call it with no keys to disable redaction.
+func (p *Plugin) SetRedactedKeys(keys ...string) {
+	p.redactedKeys.Store(newRedactionSet(keys))
+}
+
+// redactionSet returns the plugin's redacted keys, DefaultRedactedKeys unless overridden
+func (p *Plugin) redactionSet() map[string]bool {
+	if set, ok := p.redactedKeys.Load().(map[string]bool); ok {
+		return set
+	}
+	return defaultRedactionSet
+}
+
+// defaultRedactionSet is the lookup set for DefaultRedactedKeys
+var defaultRedactionSet = newRedactionSet(DefaultRedactedKeys)
+
+// currentRedactionSet returns the redacted keys of the current plugin, for package-level helpers
+func currentRedactionSet() map[string]bool {
+	if currentPlugin != nil {
+		return currentPlugin.redactionSet()
+	}
+	return defaultRedactionSet
+}
+
+// newRedactionSet builds a case-insensitive lookup set of keys to redact
+func newRedactionSet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[strings.ToLower(key)] = true
+	}
+	return set
+}
+
+// mergeRedactionSets returns the union of the given sets
+func mergeRedactionSets(sets ...map[string]bool) map[string]bool {
+	merged := make(map[string]bool)
+	for _, set := range sets {
+		for key := range set {
+			merged[key] = true
+		}
+	}
+	return merged
+}
+
+// isRedactedKey reports whether key, with any REST argument prefix removed, is in keys
+func isRedactedKey(key string, keys map[string]bool) bool {
+	key = strings.ToLower(key)
+	if keys[key] {
+		return true
+	}
+	for _, prefix := range argKeyPrefixes {
+		if strings.HasPrefix(key, prefix) && keys[strings.TrimPrefix(key, prefix)] {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue returns a copy of value in which every map entry whose key is in keys
+// (case-insensitively) is replaced with "***", descending into nested maps and slices
+func redactValue(value interface{}, keys map[string]bool) interface{} {
+	if len(keys) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if isRedactedKey(key, keys) {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = redactValue(val, keys)
+		}
+		return redacted
+
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item, keys)
+		}
+		return redacted
+	}
+	return value
+}
+
+// redactArgs applies redactValue to an argument map
+func redactArgs(args map[string]interface{}, keys map[string]bool) map[string]interface{} {
+	if redacted, ok := redactValue(args, keys).(map[string]interface{}); ok {
+		return redacted
+	}
+	return args
+}
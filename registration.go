@@ -0,0 +1,122 @@
+package sdk
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// TryRegisterQuery registers a GraphQL query like RegisterQuery, but first checks that the
+// name is set and unused, the resolver is non-nil and every object type the field references
+// is registered, returning a descriptive error instead of registering a broken query.
+// The checks and the registration happen under one lock, so concurrent callers can't both
+// register the same name.
+func (p *Plugin) TryRegisterQuery(name string, field GraphQLField, resolver ResolverFunc) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.checkGraphQLRegistration("query", name, field, resolver); err != nil {
+		return err
+	}
+	p.registerQuery(name, field, resolver)
+	return nil
+}
+
+// TryRegisterMutation registers a GraphQL mutation like RegisterMutation, with the checks of TryRegisterQuery
+func (p *Plugin) TryRegisterMutation(name string, field GraphQLField, resolver ResolverFunc) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.checkGraphQLRegistration("mutation", name, field, resolver); err != nil {
+		return err
+	}
+	p.registerMutation(name, field, resolver)
+	return nil
+}
+
+// TryRegisterFunction registers a custom function like RegisterFunction, but returns an error
+// when the name is empty or already registered, or the handler is nil
+func (p *Plugin) TryRegisterFunction(name string, function FunctionHandlerFunc) error {
+	if name == "" {
+		return fmt.Errorf("function name must not be empty")
+	}
+	if function == nil {
+		return fmt.Errorf("function '%s' has a nil handler", name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.functions[name]; exists {
+		return fmt.Errorf("function '%s' is already registered", name)
+	}
+	p.functions[name] = function
+	return nil
+}
+
+// TryRegisterRESTAPI registers a REST endpoint like RegisterRESTAPI, but returns an error when
+// the method or path is invalid, the handler is nil, the route is already registered or the
+// schema can't be sent to the host
+func (p *Plugin) TryRegisterRESTAPI(endpoint RESTEndpoint, handler RESTHandlerFunc) error {
+	route := endpoint.Method + " " + endpoint.Path
+	if !knownHTTPMethods[endpoint.Method] {
+		return fmt.Errorf("REST endpoint %s has unknown HTTP method '%s'", route, endpoint.Method)
+	}
+	if !strings.HasPrefix(endpoint.Path, "/") {
+		return fmt.Errorf("REST endpoint %s: path must start with '/'", route)
+	}
+	if handler == nil {
+		return fmt.Errorf("REST endpoint %s has a nil handler", route)
+	}
+	if err := validateRESTSchema(endpoint.Schema); err != nil {
+		return fmt.Errorf("REST endpoint %s has an invalid schema: %v", route, err)
+	}
+	endpoint.Handler = endpoint.Method + "_" + endpoint.Path
+
+	p.mu.Lock()
+	if _, exists := p.restHandlers[endpoint.Handler]; exists {
+		p.mu.Unlock()
+		return fmt.Errorf("REST endpoint %s is already registered", route)
+	}
+	p.registerRESTAPI(endpoint, handler)
+	p.mu.Unlock()
+
+	log.Printf("Plugin SDK: Registered REST API %s %s", endpoint.Method, endpoint.Path)
+	return nil
+}
+
+// checkGraphQLRegistration validates a query or mutation before it is registered.
+// Callers must hold the registry lock.
+func (p *Plugin) checkGraphQLRegistration(kind, name string, field GraphQLField, resolver ResolverFunc) error {
+	if name == "" {
+		return fmt.Errorf("%s name must not be empty", kind)
+	}
+	if resolver == nil {
+		return fmt.Errorf("%s '%s' has a nil resolver", kind, name)
+	}
+	if field.Type == nil {
+		return fmt.Errorf("%s '%s' has no type", kind, name)
+	}
+
+	if _, exists := p.queries[name]; exists {
+		return fmt.Errorf("%s '%s' is already registered as a query", kind, name)
+	}
+	if _, exists := p.mutations[name]; exists {
+		return fmt.Errorf("%s '%s' is already registered as a mutation", kind, name)
+	}
+
+	refs := make(map[string]bool)
+	collectTypeReferences(field, refs)
+	var missing []string
+	for ref := range refs {
+		if _, exists := p.objectTypes[ref]; !exists {
+			missing = append(missing, ref)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%s '%s' references unregistered type '%s'", kind, name, missing[0])
+	}
+	return nil
+}
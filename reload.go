@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"fmt"
+	"log"
+)
+
+// SchemaReloadFunc is called after the schema has been rebuilt so the host can be told to re-fetch it
+type SchemaReloadFunc func()
+
+// ReloadSchema clears and rebuilds the query, mutation and REST registries at runtime.
+// The rebuild function registers against a staging plugin; the result is validated and then
+// swapped in atomically, so in-flight executions finish with the handlers they started with.
+// While rebuild runs, object types built with ObjectTypeBuilder, enums from NewEnumType, field
+// resolvers and RESTGroup routes are registered on the staging plugin too, so a failed
+// validation leaves the live plugin untouched.
+// Custom functions, object and enum types, field resolvers, debug probes and response encoders
+// registered during the rebuild are merged in, not replaced.
+// REST routes registered outside any rebuild, such as EnableHealthEndpoint and
+// EnableMetricsEndpoint, are kept unless the rebuild registers the same method and path;
+// routes from a previous rebuild that this one doesn't register again are removed.
+// On success the schema is marked dirty and OnSchemaReload callbacks run.
+func (p *Plugin) ReloadSchema(rebuild func(p *Plugin)) error {
+	staging := &Plugin{
+		name:         p.name,
+		version:      p.version,
+		apiKey:       p.apiKey,
+		queries:      make(map[string]GraphQLField),
+		mutations:    make(map[string]GraphQLField),
+		restAPIs:     make([]RESTEndpoint, 0),
+		resolvers:    make(map[string]ResolverFunc),
+		restHandlers: make(map[string]RESTHandlerFunc),
+		functions:    make(map[string]FunctionHandlerFunc),
+		objectTypes:  make(map[string]ObjectTypeDefinition),
+		enumTypes:    make(map[string]EnumTypeDefinition),
+		debugProbes:  make(map[string]DebugProbeFunc),
+
+		fieldResolvers:   make(map[string]ResolverFunc),
+		responseEncoders: make(map[string]ResponseEncoderFunc),
+		metrics:          p.metrics,
+	}
+	staging.impl = &pluginImpl{plugin: staging}
+
+	if !p.reloadStaging.CompareAndSwap(nil, staging) {
+		return fmt.Errorf("plugin '%s' is already reloading its schema", p.name)
+	}
+	func() {
+		defer p.reloadStaging.Store(nil)
+		rebuild(staging)
+	}()
+
+	// Validate the new schema against every known object type before swapping it in
+	for name, objectType := range p.GetAllObjectTypes() {
+		if _, exists := staging.objectTypes[name]; !exists {
+			staging.objectTypes[name] = objectType
+		}
+	}
+	p.mu.RLock()
+	for name, enumType := range p.enumTypes {
+		if _, exists := staging.enumTypes[name]; !exists {
+			staging.enumTypes[name] = enumType
+		}
+	}
+	p.mu.RUnlock()
+	if err := staging.Validate(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.schemaCache = nil
+	p.queries = staging.queries
+	p.mutations = staging.mutations
+	p.queryOrder = staging.queryOrder
+	p.mutationOrder = staging.mutationOrder
+	p.resolvers = staging.resolvers
+	p.restAPIs, p.restHandlers = p.mergeReloadedRoutes(staging)
+	for name, function := range staging.functions {
+		p.functions[name] = function
+	}
+	for _, name := range staging.objectTypeOrder {
+		if _, exists := p.objectTypes[name]; !exists {
+			p.objectTypeOrder = append(p.objectTypeOrder, name)
+		}
+	}
+	for name, objectType := range staging.objectTypes {
+		p.objectTypes[name] = objectType
+	}
+	for name, enumType := range staging.enumTypes {
+		p.enumTypes[name] = enumType
+	}
+	for name, resolver := range staging.fieldResolvers {
+		p.fieldResolvers[name] = resolver
+	}
+	for stage, probe := range staging.debugProbes {
+		p.debugProbes[stage] = probe
+	}
+	for contentType, encoder := range staging.responseEncoders {
+		p.responseEncoders[contentType] = encoder
+	}
+	reloadHooks := append([]SchemaReloadFunc(nil), p.reloadHooks...)
+	p.mu.Unlock()
+
+	p.schemaDirty.Store(true)
+	log.Printf("Plugin SDK: Schema reloaded for plugin '%s'", p.name)
+
+	for _, hook := range reloadHooks {
+		hook()
+	}
+	return nil
+}
+
+// mergeReloadedRoutes combines the REST routes of the live plugin that no rebuild owns with
+// the routes registered by the staging plugin. Callers must hold the registry write lock.
+func (p *Plugin) mergeReloadedRoutes(staging *Plugin) ([]RESTEndpoint, map[string]RESTHandlerFunc) {
+	apis := make([]RESTEndpoint, 0, len(p.restAPIs)+len(staging.restAPIs))
+	handlers := make(map[string]RESTHandlerFunc, len(p.restHandlers)+len(staging.restHandlers))
+
+	for _, endpoint := range p.restAPIs {
+		if p.reloadOwnedRoutes[endpoint.Handler] {
+			continue
+		}
+		if _, replaced := staging.restHandlers[endpoint.Handler]; replaced {
+			continue
+		}
+		apis = append(apis, endpoint)
+		handlers[endpoint.Handler] = p.restHandlers[endpoint.Handler]
+	}
+
+	owned := make(map[string]bool, len(staging.restAPIs))
+	for _, endpoint := range staging.restAPIs {
+		apis = append(apis, endpoint)
+		handlers[endpoint.Handler] = staging.restHandlers[endpoint.Handler]
+		owned[endpoint.Handler] = true
+	}
+	p.reloadOwnedRoutes = owned
+
+	return apis, handlers
+}
+
+// reloadTarget returns the staging plugin while a ReloadSchema rebuild is running, and p otherwise
+func (p *Plugin) reloadTarget() *Plugin {
+	if staging := p.reloadStaging.Load(); staging != nil {
+		return staging
+	}
+	return p
+}
+
+// registrationPlugin returns the plugin that package-level builders register with:
+// the current plugin, or its staging plugin during ReloadSchema
+func registrationPlugin() *Plugin {
+	if currentPlugin == nil {
+		return nil
+	}
+	return currentPlugin.reloadTarget()
+}
+
+// OnSchemaReload registers a callback run after every successful ReloadSchema,
+// typically used to ask the host to call SchemaRegister and RESTApiRegister again
+func (p *Plugin) OnSchemaReload(hook SchemaReloadFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reloadHooks = append(p.reloadHooks, hook)
+}
+
+// IsSchemaDirty reports whether the schema changed since the host last fetched it
+func (p *Plugin) IsSchemaDirty() bool {
+	return p.schemaDirty.Load()
+}
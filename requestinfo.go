@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+// RequestInfo describes the request currently being executed
+type RequestInfo struct {
+	FunctionType string
+	FunctionName string
+	StartedAt    time.Time
+	// RequestID is the host-supplied "request_id" context value, or a generated UUID when absent
+	RequestID string
+}
+
+// requestInfoKey is the context key under which Execute stores the RequestInfo
+type requestInfoKey struct{}
+
+// GetRequestInfo returns the metadata of the request being executed, if ctx came from Execute
+func GetRequestInfo(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// withRequestInfo attaches the request's metadata to ctx
+func withRequestInfo(ctx context.Context, req *protobuff.ExecuteRequest, contextData map[string]interface{}) context.Context {
+	requestID, _ := contextData["request_id"].(string)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+
+	return context.WithValue(ctx, requestInfoKey{}, RequestInfo{
+		FunctionType: req.FunctionType,
+		FunctionName: req.FunctionName,
+		StartedAt:    time.Now(),
+		RequestID:    requestID,
+	})
+}
+
+// GetRequestID returns the correlation ID of the request being executed: the host-supplied
+// "request_id" context value, or a UUID generated by Execute. It is empty outside Execute.
+func GetRequestID(ctx context.Context) string {
+	if info, ok := GetRequestInfo(ctx); ok {
+		return info.RequestID
+	}
+	return GetContextFromContext(ctx, "request_id")
+}
+
+// newRequestID generates a random (version 4) UUID
+func newRequestID() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+	return string(buf)
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+)
+
+// requestValues is a mutable per-request store shared by every context derived from the
+// request's context, so values set by middleware are visible to the resolver
+type requestValues struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+}
+
+// requestValuesKey is the context key under which Execute stores the request's value store
+type requestValuesKey struct{}
+
+// withRequestValues attaches an empty value store to ctx
+func withRequestValues(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestValuesKey{}, &requestValues{values: make(map[string]interface{})})
+}
+
+// SetRequestValue stores a value for the rest of the request, e.g. a user loaded by auth
+// middleware for reuse in the resolver. Inside Execute the store is shared, so the returned
+// context is ctx itself; outside Execute a new store is attached and the returned context
+// must be used.
+func SetRequestValue(ctx context.Context, key string, value interface{}) context.Context {
+	store, ok := ctx.Value(requestValuesKey{}).(*requestValues)
+	if !ok {
+		ctx = withRequestValues(ctx)
+		store = ctx.Value(requestValuesKey{}).(*requestValues)
+	}
+
+	store.mu.Lock()
+	store.values[key] = value
+	store.mu.Unlock()
+	return ctx
+}
+
+// GetRequestValue returns a value stored with SetRequestValue during the current request
+func GetRequestValue(ctx context.Context, key string) (interface{}, bool) {
+	store, ok := ctx.Value(requestValuesKey{}).(*requestValues)
+	if !ok {
+		return nil, false
+	}
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	value, exists := store.values[key]
+	return value, exists
+}
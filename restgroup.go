@@ -0,0 +1,103 @@
+package sdk
+
+import "strings"
+
+// RESTMiddleware wraps a REST handler, e.g. to enforce auth for every route in a RESTGroup.
+// Resolver middleware such as RateLimitMiddleware can be adapted with
+// func(next RESTHandlerFunc) RESTHandlerFunc { return RESTHandlerFunc(RateLimitMiddleware(60, ResolverFunc(next))) }.
+type RESTMiddleware func(next RESTHandlerFunc) RESTHandlerFunc
+
+// RESTGroup registers REST endpoints that share a path prefix and middleware
+type RESTGroup struct {
+	plugin     *Plugin
+	prefix     string
+	middleware []RESTMiddleware
+}
+
+// RESTGroup returns a route group whose endpoints are registered under prefix and wrapped
+// in mw. Middleware runs in the order given, the first one being outermost.
+func (p *Plugin) RESTGroup(prefix string, mw ...RESTMiddleware) *RESTGroup {
+	return &RESTGroup{
+		plugin:     p,
+		prefix:     normalizeGroupPrefix(prefix),
+		middleware: append([]RESTMiddleware(nil), mw...),
+	}
+}
+
+// Group returns a nested group; its prefix is appended to this group's prefix and its
+// middleware runs inside this group's middleware
+func (g *RESTGroup) Group(prefix string, mw ...RESTMiddleware) *RESTGroup {
+	middleware := make([]RESTMiddleware, 0, len(g.middleware)+len(mw))
+	middleware = append(middleware, g.middleware...)
+	middleware = append(middleware, mw...)
+
+	return &RESTGroup{
+		plugin:     g.plugin,
+		prefix:     g.prefix + normalizeGroupPrefix(prefix),
+		middleware: middleware,
+	}
+}
+
+// Register registers an endpoint built elsewhere (e.g. with NewRESTEndpoint) under the group
+// prefix and middleware, and returns the endpoint as registered
+func (g *RESTGroup) Register(endpoint RESTEndpoint, handler RESTHandlerFunc) RESTEndpoint {
+	endpoint.Path = g.path(endpoint.Path)
+	for i := len(g.middleware) - 1; i >= 0; i-- {
+		handler = g.middleware[i](handler)
+	}
+
+	g.plugin.reloadTarget().RegisterRESTAPI(endpoint, handler)
+	endpoint.Handler = endpoint.Method + "_" + endpoint.Path
+	return endpoint
+}
+
+// GET registers a GET endpoint in the group
+func (g *RESTGroup) GET(path, description string, handler RESTHandlerFunc) RESTEndpoint {
+	return g.Register(GETEndpoint(path, description).Build(), handler)
+}
+
+// POST registers a POST endpoint in the group
+func (g *RESTGroup) POST(path, description string, handler RESTHandlerFunc) RESTEndpoint {
+	return g.Register(POSTEndpoint(path, description).Build(), handler)
+}
+
+// PUT registers a PUT endpoint in the group
+func (g *RESTGroup) PUT(path, description string, handler RESTHandlerFunc) RESTEndpoint {
+	return g.Register(PUTEndpoint(path, description).Build(), handler)
+}
+
+// PATCH registers a PATCH endpoint in the group
+func (g *RESTGroup) PATCH(path, description string, handler RESTHandlerFunc) RESTEndpoint {
+	return g.Register(PATCHEndpoint(path, description).Build(), handler)
+}
+
+// DELETE registers a DELETE endpoint in the group
+func (g *RESTGroup) DELETE(path, description string, handler RESTHandlerFunc) RESTEndpoint {
+	return g.Register(DELETEEndpoint(path, description).Build(), handler)
+}
+
+// Prefix returns the full path prefix of the group
+func (g *RESTGroup) Prefix() string {
+	return g.prefix
+}
+
+// path joins the group prefix with an endpoint path; "/" and "" map to the prefix itself
+func (g *RESTGroup) path(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		if g.prefix == "" {
+			return "/"
+		}
+		return g.prefix
+	}
+	return g.prefix + "/" + path
+}
+
+// normalizeGroupPrefix gives a prefix exactly one leading slash and no trailing slash
+func normalizeGroupPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
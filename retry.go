@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryOptions configures WithRetry
+type RetryOptions struct {
+	MaxAttempts int           // Total attempts including the first call (default 3)
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each attempt (default 100ms)
+	MaxDelay    time.Duration // Upper bound for a single delay (default 5s)
+}
+
+// WithRetry wraps a function so retryable errors (see IsRetryable) are retried with exponential
+// backoff and jitter. 4xx CodedErrors are never retried, and no retry is scheduled past the
+// context deadline; the last error is returned when attempts run out.
+func WithRetry(fn FunctionHandlerFunc, opts RetryOptions) FunctionHandlerFunc {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 100 * time.Millisecond
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 5 * time.Second
+	}
+
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		var result interface{}
+		var err error
+		for attempt := 0; attempt < opts.MaxAttempts; attempt++ {
+			result, err = fn(ctx, args)
+			if err == nil || !shouldRetry(err) || attempt == opts.MaxAttempts-1 {
+				return result, err
+			}
+
+			delay := retryDelay(attempt, opts)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return result, err
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return result, err
+			case <-timer.C:
+			}
+		}
+		return result, err
+	}
+}
+
+// shouldRetry reports whether WithRetry may retry an error
+func shouldRetry(err error) bool {
+	if codedErr := GetCodedError(err); codedErr != nil && codedErr.Code >= 400 && codedErr.Code < 500 {
+		return false
+	}
+	return IsRetryable(err)
+}
+
+// retryDelay is the exponential backoff for an attempt, capped at MaxDelay and jittered
+// between half and the full delay
+func retryDelay(attempt int, opts RetryOptions) time.Duration {
+	delay := opts.BaseDelay << attempt
+	if delay <= 0 || delay > opts.MaxDelay {
+		delay = opts.MaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+)
+
+// SetRejectNonFiniteFloats controls how NaN and ±Inf values in results are handled. By default they
+// are replaced with null; when enabled, the request fails instead.
+func (p *Plugin) SetRejectNonFiniteFloats(enabled bool) {
+	p.rejectNonFiniteFloats.Store(enabled)
+}
+
+// sanitizeResult prepares a result for structpb conversion, which rejects non-string map keys and
+// non-finite floats. Non-string keys are stringified and NaN/Inf values become null (or an error,
+// see SetRejectNonFiniteFloats); a warning is logged whenever the data had to be changed.
+func (p *Plugin) sanitizeResult(functionName string, result interface{}) (interface{}, error) {
+	s := &resultSanitizer{rejectNonFinite: p.rejectNonFiniteFloats.Load()}
+	sanitized := s.sanitize("data", result)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.changes) > 0 {
+		log.Printf("SDK Warning: Result of '%s' was sanitized for serialization: %v", functionName, s.changes)
+	}
+	return sanitized, nil
+}
+
+// resultSanitizer walks a result, recording each path it had to change
+type resultSanitizer struct {
+	rejectNonFinite bool
+	changes         []string
+	err             error
+}
+
+// sanitize returns value with maps, slices and pointers normalized to the generic JSON types.
+// Structs and other values are returned unchanged.
+func (s *resultSanitizer) sanitize(path string, value interface{}) interface{} {
+	if value == nil || s.err != nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string, bool, []byte:
+		return v
+	case float64:
+		return s.sanitizeFloat(path, v)
+	case float32:
+		return s.sanitizeFloat(path, float64(v))
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return s.sanitize(path, val.Elem().Interface())
+
+	case reflect.Map:
+		sanitized := make(map[string]interface{}, val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			var name string
+			if key.Kind() == reflect.String {
+				name = key.String()
+			} else {
+				name = fmt.Sprintf("%v", key.Interface())
+				s.changes = append(s.changes, fmt.Sprintf("%s: stringified %s key %s", path, key.Type(), name))
+			}
+			sanitized[name] = s.sanitize(path+"."+name, iter.Value().Interface())
+		}
+		return sanitized
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return nil
+		}
+		sanitized := make([]interface{}, val.Len())
+		for i := range sanitized {
+			sanitized[i] = s.sanitize(fmt.Sprintf("%s[%d]", path, i), val.Index(i).Interface())
+		}
+		return sanitized
+	}
+
+	return value
+}
+
+// sanitizeFloat replaces a NaN or infinite value with nil, or records an error when they're rejected
+func (s *resultSanitizer) sanitizeFloat(path string, f float64) interface{} {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	if s.rejectNonFinite {
+		s.err = InternalServerError("Invalid result", fmt.Sprintf("%s: %v is not a valid JSON number", path, f))
+		return nil
+	}
+	s.changes = append(s.changes, fmt.Sprintf("%s: replaced %v with null", path, f))
+	return nil
+}
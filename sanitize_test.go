@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+func TestExecuteSanitizesResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		result interface{}
+		want   interface{}
+	}{
+		{"int map keys", map[int]string{1: "one", 2: "two"}, map[string]interface{}{"1": "one", "2": "two"}},
+		{"NaN", math.NaN(), nil},
+		{"infinity", math.Inf(1), nil},
+		{"NaN in a map", map[string]interface{}{"score": math.NaN(), "name": "x"}, map[string]interface{}{"score": nil, "name": "x"}},
+		{"nested int keys", map[string]interface{}{"byID": map[int]float64{7: 1.5}}, map[string]interface{}{"byID": map[string]interface{}{"7": 1.5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Init("test-plugin", "1.0.0", "")
+			p.RegisterFunction("compute", FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				return tt.result, nil
+			}))
+
+			resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "function", FunctionName: "compute"})
+			if err != nil || !resp.Success {
+				t.Fatalf("Execute() = %v, %v", resp, err)
+			}
+			got, err := DecodeExecuteResult(resp.Result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRejectsNonFiniteFloats(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.SetRejectNonFiniteFloats(true)
+	p.RegisterFunction("compute", FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return map[string]interface{}{"score": math.NaN()}, nil
+	}))
+
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "function", FunctionName: "compute"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Success {
+		t.Fatalf("Execute() succeeded with a NaN result: %v", resp)
+	}
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import "sort"
+
+// SchemaModel is the ordered, unserialized view of everything a plugin exposes. SchemaRegister
+// and introspection are built from it, so exporters and tests can consume the same source.
+type SchemaModel struct {
+	Queries     []SchemaField          // In registration order, including internal fields
+	Mutations   []SchemaField          // In registration order, including internal fields
+	Functions   []string               // Sorted by name
+	ObjectTypes []ObjectTypeDefinition // In registration order
+	EnumTypes   []EnumTypeDefinition   // Sorted by name
+}
+
+// SchemaField is a named query or mutation in a SchemaModel
+type SchemaField struct {
+	Name  string
+	Field GraphQLField
+}
+
+// SchemaModel returns a snapshot of the plugin's schema. The returned definitions share
+// their argument and field maps with the registries and must not be modified.
+func (p *Plugin) SchemaModel() SchemaModel {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.schemaModel()
+}
+
+// schemaModel builds the schema model. Callers must hold the registry lock.
+func (p *Plugin) schemaModel() SchemaModel {
+	model := SchemaModel{
+		Queries:     make([]SchemaField, 0, len(p.queryOrder)),
+		Mutations:   make([]SchemaField, 0, len(p.mutationOrder)),
+		Functions:   make([]string, 0, len(p.functions)),
+		ObjectTypes: make([]ObjectTypeDefinition, 0, len(p.objectTypeOrder)),
+		EnumTypes:   make([]EnumTypeDefinition, 0, len(p.enumTypes)),
+	}
+
+	for _, name := range p.queryOrder {
+		model.Queries = append(model.Queries, SchemaField{Name: name, Field: p.queries[name]})
+	}
+	for _, name := range p.mutationOrder {
+		model.Mutations = append(model.Mutations, SchemaField{Name: name, Field: p.mutations[name]})
+	}
+
+	for name := range p.functions {
+		model.Functions = append(model.Functions, name)
+	}
+	sort.Strings(model.Functions)
+
+	for _, name := range p.objectTypeOrder {
+		model.ObjectTypes = append(model.ObjectTypes, p.objectTypes[name])
+	}
+
+	for _, enumType := range p.enumTypes {
+		model.EnumTypes = append(model.EnumTypes, enumType)
+	}
+	sort.Slice(model.EnumTypes, func(i, j int) bool {
+		return model.EnumTypes[i].TypeName < model.EnumTypes[j].TypeName
+	})
+
+	return model
+}
+
+// serializeSchemaFields converts public fields to the engine's format. Structs are maps,
+// so each entry carries its "position" for hosts that want to keep the declared order.
+func (impl *pluginImpl) serializeSchemaFields(fields []SchemaField) map[string]interface{} {
+	serializedFields := make(map[string]interface{}, len(fields))
+	for _, schemaField := range fields {
+		// Internal fields are executable but not part of the public schema
+		if schemaField.Field.Internal {
+			continue
+		}
+		serialized := impl.serializeGraphQLField(schemaField.Field)
+		serialized["position"] = len(serializedFields)
+		serializedFields[schemaField.Name] = serialized
+	}
+	return serializedFields
+}
@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+)
+
+func TestInternalFieldIsExecutableButNotSerialized(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	resolver := func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	p.RegisterQuery("publicStats", Field("String", "Public stats"), resolver)
+	p.RegisterQuery("internalStats", Field("String", "Internal stats").AsInternal(), resolver)
+	p.RegisterMutation("internalReset", Field("String", "Internal reset").AsInternal(), resolver)
+
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{FunctionType: "graphql_query", FunctionName: "internalStats"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Execute(internalStats) = %v, %v", resp, err)
+	}
+	if data, err := DecodeExecuteResult(resp.Result); err != nil || data != "ok" {
+		t.Errorf("internalStats result = %v, %v", data, err)
+	}
+
+	schema, err := p.impl.cachedSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := schema.Queries.AsMap()
+	if _, exists := queries["internalStats"]; exists {
+		t.Error("internal query is part of the serialized schema")
+	}
+	if _, exists := queries["publicStats"]; !exists {
+		t.Errorf("public query missing from the serialized schema: %v", queries)
+	}
+	if _, exists := schema.Mutations.AsMap()["internalReset"]; exists {
+		t.Error("internal mutation is part of the serialized schema")
+	}
+}
+
+func TestDeprecationIsSerialized(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	resolver := func(ctx context.Context, args map[string]interface{}) (interface{}, error) { return nil, nil }
+
+	p.RegisterQuery("oldSearch", FieldWithArgs("String", "Old search", map[string]interface{}{
+		"term":  StringArg("Search term"),
+		"limit": DeprecatedArg(IntArg("Result limit"), "Use first"),
+	}).Deprecated("Use search"), resolver)
+	p.RegisterQuery("legacyStats", Field("String", "Legacy stats").Deprecated(""), resolver)
+	p.RegisterQuery("search", Field("String", "Search"), resolver)
+
+	schema, err := p.impl.cachedSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := schema.Queries.AsMap()
+	oldSearch := queries["oldSearch"].(map[string]interface{})
+
+	account := NewObjectType("Account", "An account").
+		AddStringField("email", "Email", false).
+		AddStringField("username", "Username", true).
+		DeprecateField("username", "Use email").
+		Build()
+	accountFields := p.impl.serializeObjectTypeDefinition(account)["fields"].(map[string]interface{})
+
+	tests := []struct {
+		name       string
+		serialized interface{}
+		deprecated bool
+		reason     string
+	}{
+		{"field with reason", oldSearch, true, "Use search"},
+		{"field with default reason", queries["legacyStats"], true, DefaultDeprecationReason},
+		{"field not deprecated", queries["search"], false, ""},
+		{"argument", serializedArg(t, oldSearch, "limit"), true, "Use first"},
+		{"argument not deprecated", serializedArg(t, oldSearch, "term"), false, ""},
+		{"object type field", accountFields["username"], true, "Use email"},
+		{"object type field not deprecated", accountFields["email"], false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serialized, ok := tt.serialized.(map[string]interface{})
+			if !ok {
+				t.Fatalf("serialized definition = %#v", tt.serialized)
+			}
+			deprecated, _ := serialized["deprecated"].(bool)
+			reason, _ := serialized["deprecationReason"].(string)
+			if deprecated != tt.deprecated || reason != tt.reason {
+				t.Errorf("deprecated = %v, reason = %q; want %v, %q", deprecated, reason, tt.deprecated, tt.reason)
+			}
+		})
+	}
+}
+
+// serializedArg finds an argument of a serialized field in either argument format
+func serializedArg(t *testing.T, field map[string]interface{}, name string) map[string]interface{} {
+	t.Helper()
+	switch args := field["args"].(type) {
+	case map[string]interface{}:
+		if arg, ok := args[name].(map[string]interface{}); ok {
+			return arg
+		}
+	case []interface{}:
+		for _, item := range args {
+			if arg, ok := item.(map[string]interface{}); ok && arg["name"] == name {
+				return arg
+			}
+		}
+	}
+	t.Fatalf("argument %q not found in %v", name, field["args"])
+	return nil
+}
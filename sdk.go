@@ -1,20 +1,26 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apito-io/types/protobuff"
 	"github.com/hashicorp/go-hclog"
 	hcplugin "github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -28,6 +34,7 @@ type CodedError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	Err     error  `json:"-"` // Underlying cause, if any
 }
 
 // GraphQLError represents a GraphQL-specific error
@@ -60,6 +67,11 @@ func (e *CodedError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.Code, e.Message)
 }
 
+// Unwrap returns the underlying cause so errors.Is/As can inspect it
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
 func (e *GraphQLError) Error() string {
 	return e.Message
 }
@@ -76,6 +88,19 @@ func ErrorWithCode(code int, message string, details ...string) error {
 	return err
 }
 
+// WrapWithCode wraps an existing error with an HTTP status code, keeping it available to errors.Is/As
+func WrapWithCode(code int, message string, err error) error {
+	codedErr := &CodedError{
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+	if err != nil {
+		codedErr.Details = err.Error()
+	}
+	return codedErr
+}
+
 // Common HTTP error constructors
 func BadRequestError(message string, details ...string) error {
 	return ErrorWithCode(400, message, details...)
@@ -93,10 +118,39 @@ func NotFoundError(message string, details ...string) error {
 	return ErrorWithCode(404, message, details...)
 }
 
+func ConflictError(message string, details ...string) error {
+	return ErrorWithCode(409, message, details...)
+}
+
+func PayloadTooLargeError(message string, details ...string) error {
+	return ErrorWithCode(413, message, details...)
+}
+
+func UnprocessableEntityError(message string, details ...string) error {
+	return ErrorWithCode(422, message, details...)
+}
+
+func TooManyRequestsError(message string, details ...string) error {
+	return ErrorWithCode(429, message, details...)
+}
+
 func InternalServerError(message string, details ...string) error {
 	return ErrorWithCode(500, message, details...)
 }
 
+func ServiceUnavailableError(message string, details ...string) error {
+	return ErrorWithCode(503, message, details...)
+}
+
+func GatewayTimeoutError(message string, details ...string) error {
+	return ErrorWithCode(504, message, details...)
+}
+
+// CodedErrorf creates a new error with HTTP status code and a formatted message
+func CodedErrorf(code int, format string, args ...interface{}) error {
+	return ErrorWithCode(code, fmt.Sprintf(format, args...))
+}
+
 // GraphQL Error constructors
 
 // GraphQLErrorWithMessage creates a basic GraphQL error with just a message
@@ -188,9 +242,48 @@ func GraphQLBadUserInputError(message string, field ...string) error {
 	}
 }
 
-// GetErrorCode extracts HTTP status code from error, returns 500 for unknown errors
+// NewGraphQLError creates a GraphQL error with a response path (field names and list
+// indices, e.g. []interface{}{"users", 2, "email"}) and extensions; either may be nil
+func NewGraphQLError(message string, path []interface{}, extensions map[string]interface{}) *GraphQLError {
+	return &GraphQLError{
+		Message:    message,
+		Path:       path,
+		Extensions: extensions,
+	}
+}
+
+// WithPath sets the response path of the error and returns it
+func (e *GraphQLError) WithPath(path ...interface{}) *GraphQLError {
+	e.Path = path
+	return e
+}
+
+// WithExtension sets one extensions entry, such as "code", and returns the error
+func (e *GraphQLError) WithExtension(key string, value interface{}) *GraphQLError {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]interface{})
+	}
+	e.Extensions[key] = value
+	return e
+}
+
+// graphQLErrorCodes maps HTTP status codes of CodedErrors to GraphQL extensions codes
+var graphQLErrorCodes = map[int]string{
+	400: "BAD_USER_INPUT",
+	401: "UNAUTHENTICATED",
+	403: "FORBIDDEN",
+	404: "NOT_FOUND",
+	409: "CONFLICT",
+	413: "PAYLOAD_TOO_LARGE",
+	422: "BAD_USER_INPUT",
+	429: "TOO_MANY_REQUESTS",
+	503: "SERVICE_UNAVAILABLE",
+	504: "TIMEOUT",
+}
+
+// GetErrorCode extracts HTTP status code from error (including wrapped errors), returns 500 for unknown errors
 func GetErrorCode(err error) int {
-	if codedErr, ok := err.(*CodedError); ok {
+	if codedErr := GetCodedError(err); codedErr != nil {
 		return codedErr.Code
 	}
 	return 500 // Default to internal server error
@@ -201,29 +294,128 @@ func GetErrorMessage(err error) string {
 	if err == nil {
 		return ""
 	}
+	if codedErr := GetCodedError(err); codedErr != nil {
+		return codedErr.Message
+	}
 	return err.Error()
 }
 
-// IsGraphQLError checks if an error is a GraphQL error
+// IsGraphQLError checks if an error is (or wraps) a GraphQL error
 func IsGraphQLError(err error) bool {
-	_, ok := err.(*GraphQLError)
-	return ok
+	return GetGraphQLError(err) != nil
 }
 
-// IsCodedError checks if an error is a coded HTTP error
+// IsCodedError checks if an error is (or wraps) a coded HTTP error
 func IsCodedError(err error) bool {
-	_, ok := err.(*CodedError)
-	return ok
+	return GetCodedError(err) != nil
 }
 
-// GetGraphQLError safely extracts GraphQL error details
+// GetCodedError safely extracts coded error details, unwrapping as needed
+func GetCodedError(err error) *CodedError {
+	var codedErr *CodedError
+	if errors.As(err, &codedErr) {
+		return codedErr
+	}
+	return nil
+}
+
+// GetGraphQLError safely extracts GraphQL error details, unwrapping as needed
 func GetGraphQLError(err error) *GraphQLError {
-	if gqlErr, ok := err.(*GraphQLError); ok {
+	var gqlErr *GraphQLError
+	if errors.As(err, &gqlErr) {
 		return gqlErr
 	}
 	return nil
 }
 
+// buildErrorObject converts an error into a protobuf-compatible map with its HTTP status code
+func buildErrorObject(err error) map[string]interface{} {
+	errorObj := map[string]interface{}{
+		"code":    GetErrorCode(err),
+		"message": err.Error(),
+	}
+
+	if codedErr := GetCodedError(err); codedErr != nil {
+		errorObj["message"] = codedErr.Message
+		if codedErr.Details != "" {
+			errorObj["details"] = codedErr.Details
+		}
+	}
+
+	if gqlErr := GetGraphQLError(err); gqlErr != nil {
+		if code, ok := gqlErr.Extensions["code"].(string); ok {
+			errorObj["type"] = code
+		}
+	}
+
+	// Retry hint so the host doesn't have to guess from the message
+	errorObj["retryable"] = IsRetryable(err)
+	if backoff := GetRetryBackoff(err); backoff > 0 {
+		errorObj["retryAfterMs"] = backoff.Milliseconds()
+	}
+
+	return errorObj
+}
+
+// DefaultRetryBackoff is the suggested backoff for retryable errors without an explicit one
+const DefaultRetryBackoff = time.Second
+
+// RetryableError marks an error as safe for the host to retry
+type RetryableError struct {
+	Err     error
+	Backoff time.Duration
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error so errors.Is/As can inspect it
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable marks an error as transient and safe to retry with the default backoff
+func Retryable(err error) error {
+	return RetryableAfter(err, DefaultRetryBackoff)
+}
+
+// RetryableAfter marks an error as transient and safe to retry after the given backoff
+func RetryableAfter(err error, backoff time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryableError{Err: err, Backoff: backoff}
+}
+
+// IsRetryable reports whether an error is safe to retry. Errors marked with Retryable are
+// retryable, as are 503 Service Unavailable coded errors by default.
+func IsRetryable(err error) bool {
+	var retryErr *RetryableError
+	if errors.As(err, &retryErr) {
+		return true
+	}
+	if codedErr := GetCodedError(err); codedErr != nil {
+		return codedErr.Code == 503
+	}
+	return false
+}
+
+// GetRetryBackoff returns the suggested backoff for a retryable error, or zero if it isn't retryable
+func GetRetryBackoff(err error) time.Duration {
+	var retryErr *RetryableError
+	if errors.As(err, &retryErr) {
+		return retryErr.Backoff
+	}
+	if IsRetryable(err) {
+		return DefaultRetryBackoff
+	}
+	return 0
+}
+
+// DefaultMaxResponseBytes matches gRPC's default 4MB maximum message size
+const DefaultMaxResponseBytes int64 = 4 << 20
+
 // Global plugin instance for resolver access
 var currentPlugin *Plugin
 
@@ -236,15 +428,48 @@ type RESTHandlerFunc func(ctx context.Context, args map[string]interface{}) (int
 // FunctionHandlerFunc is the function signature for custom functions
 type FunctionHandlerFunc func(ctx context.Context, args map[string]interface{}) (interface{}, error)
 
+// InitHookFunc is the function signature for hooks run when the host initializes the plugin
+type InitHookFunc func(ctx context.Context, env map[string]string) error
+
+// ShutdownHookFunc is the function signature for hooks run when the plugin server stops
+type ShutdownHookFunc func(ctx context.Context) error
+
+// DebugProbeFunc is the function signature for debug probes run by the Debug RPC
+type DebugProbeFunc func(ctx context.Context) (map[string]interface{}, error)
+
 // HealthCheckFunc is the function signature for custom health checks
 type HealthCheckFunc func(ctx context.Context) (map[string]interface{}, error)
 
 // GraphQLField represents a GraphQL field definition
 type GraphQLField struct {
-	Type        interface{}            `json:"type"` // Can be string or GraphQLTypeDefinition
-	Description string                 `json:"description"`
-	Args        map[string]interface{} `json:"args,omitempty"`
-	Resolve     string                 `json:"resolve"`
+	Type              interface{}            `json:"type"` // Can be string or GraphQLTypeDefinition
+	Description       string                 `json:"description"`
+	Args              map[string]interface{} `json:"args,omitempty"`
+	Resolve           string                 `json:"resolve"`
+	IsDeprecated      bool                   `json:"deprecated,omitempty"`
+	DeprecationReason string                 `json:"deprecationReason,omitempty"`
+	RateLimit         int                    `json:"rateLimit,omitempty"` // Requests per minute per caller, 0 means unlimited
+	Internal          bool                   `json:"internal,omitempty"`  // Left out of SchemaRegister but still executable by name
+}
+
+// DefaultDeprecationReason is the GraphQL spec default reason for the @deprecated directive
+const DefaultDeprecationReason = "No longer supported"
+
+// Deprecated returns a copy of the field marked as deprecated with the given reason
+func (f GraphQLField) Deprecated(reason string) GraphQLField {
+	if reason == "" {
+		reason = DefaultDeprecationReason
+	}
+	f.IsDeprecated = true
+	f.DeprecationReason = reason
+	return f
+}
+
+// AsInternal returns a copy of the field marked as internal: its resolver stays invokable
+// by name through Execute, but the field is left out of the public schema sent by SchemaRegister
+func (f GraphQLField) AsInternal() GraphQLField {
+	f.Internal = true
+	return f
 }
 
 // GraphQLTypeDefinition represents a complex GraphQL type
@@ -256,30 +481,94 @@ type GraphQLTypeDefinition struct {
 	ScalarType string                 `json:"scalarType"` // For scalar types: "String", "Int", "Boolean", "Float"
 }
 
+// WithRateLimit returns a copy of the field with a per-caller rate limit the host should enforce
+func (f GraphQLField) WithRateLimit(requestsPerMinute int) GraphQLField {
+	f.RateLimit = requestsPerMinute
+	return f
+}
+
 // RESTEndpoint represents a REST API endpoint definition
 type RESTEndpoint struct {
-	Method      string
-	Path        string
-	Description string
-	Schema      map[string]interface{}
-	Handler     string
+	Method          string
+	Path            string
+	Description     string
+	Schema          map[string]interface{}
+	Handler         string
+	MaxRequestBytes int64 // Overrides the plugin-wide request size limit when non-zero; negative disables it
 }
 
 // Plugin represents the SDK plugin instance
 type Plugin struct {
-	name         string
-	version      string
-	apiKey       string
-	queries      map[string]GraphQLField
-	mutations    map[string]GraphQLField
-	restAPIs     []RESTEndpoint
-	resolvers    map[string]ResolverFunc
-	restHandlers map[string]RESTHandlerFunc
-	functions    map[string]FunctionHandlerFunc
-	healthChecks []HealthCheckFunc
-
-	// Type registry for nested objects
-	objectTypes map[string]ObjectTypeDefinition
+	name          string
+	version       string
+	apiKey        string
+	queries       map[string]GraphQLField
+	mutations     map[string]GraphQLField
+	restAPIs      []RESTEndpoint
+	resolvers     map[string]ResolverFunc
+	restHandlers  map[string]RESTHandlerFunc
+	functions     map[string]FunctionHandlerFunc
+	healthChecks  []HealthCheckFunc
+	migrations    []migrationStep
+	initHooks     []InitHookFunc
+	shutdownHooks []ShutdownHookFunc
+	beforeHooks   []BeforeExecuteFunc
+	afterHooks    []AfterExecuteFunc
+	debugProbes   map[string]DebugProbeFunc
+
+	// Type registry for nested objects and the enums their fields reference
+	objectTypes    map[string]ObjectTypeDefinition
+	enumTypes      map[string]EnumTypeDefinition
+	fieldResolvers map[string]ResolverFunc // Keyed by "TypeName.fieldName"
+
+	// Registration order of queries, mutations and object types, so the schema is emitted
+	// in a stable order rather than Go's random map order
+	queryOrder      []string
+	mutationOrder   []string
+	objectTypeOrder []string
+
+	// Schema reload state
+	reloadHooks []SchemaReloadFunc
+	schemaDirty atomic.Bool
+	schemaCache *protobuff.ThirdPartyGraphQLSchemas
+
+	// Payload limits and response shaping options
+	maxRequestBytes       atomic.Int64
+	maxResponseBytes      atomic.Int64
+	maxGRPCMessageSize    atomic.Int64
+	restEnvelope          atomic.Bool
+	debugMode             atomic.Bool
+	rejectNonFiniteFloats atomic.Bool
+	strictArgs            atomic.Bool
+	argFormat             atomic.Int32 // ArgFormatLegacy when unset
+	executionTimeout      atomic.Int64 // time.Duration
+	responseEncoders      map[string]ResponseEncoderFunc
+	logger                hclog.Logger
+	cors                  *CORSConfig
+	idempotencyStore      IdempotencyStore
+	idempotencyTTL        time.Duration
+	idempotencyMu         sync.Mutex
+	reloadStaging         atomic.Pointer[Plugin]     // Set while ReloadSchema runs its rebuild callback
+	reloadOwnedRoutes     map[string]bool            // REST handler keys registered by the last rebuild
+	idempotencyInFlight   map[string]*idempotentCall // Executions in progress, keyed like the store
+	circuitBreakers       circuitBreakerRegistry
+	auditLogger           AuditLogger
+	auditRedactions       map[string]bool
+	redactedKeys          atomic.Value // map[string]bool
+	messageCatalog        map[string]map[string]string
+	errorMappings         []errorMapping
+	restTimestampFormat   atomic.Value // string
+	argTransformers       map[string]ArgTransformerFunc
+	draining              atomic.Bool
+	startedAt             time.Time
+	healthDiskPath        string
+	healthThresholds      HealthThresholds
+	metrics               *metricsRegistry
+	keepalive             *KeepaliveConfig
+	typeNamespace         atomic.Value // string
+
+	// mu guards the registries above against concurrent registration and execution
+	mu sync.RWMutex
 
 	// Internal implementation
 	impl *pluginImpl
@@ -304,36 +593,107 @@ func Init(name, version, apiKey string) *Plugin {
 		restHandlers: make(map[string]RESTHandlerFunc),
 		functions:    make(map[string]FunctionHandlerFunc),
 		healthChecks: make([]HealthCheckFunc, 0),
+		migrations:   make([]migrationStep, 0),
+		initHooks:    make([]InitHookFunc, 0),
+		debugProbes:  make(map[string]DebugProbeFunc),
 		objectTypes:  make(map[string]ObjectTypeDefinition),
+		enumTypes:    make(map[string]EnumTypeDefinition),
+		startedAt:    time.Now(),
+
+		fieldResolvers: make(map[string]ResolverFunc),
+		responseEncoders: map[string]ResponseEncoderFunc{
+			ContentTypeJSON: JSONEncoder,
+			ContentTypeCSV:  CSVEncoder,
+		},
 	}
 
 	p.impl = &pluginImpl{plugin: p}
+	p.metrics = newMetricsRegistry()
+	p.maxResponseBytes.Store(DefaultMaxResponseBytes)
 
 	// Register built-in health check function
 	p.functions["health_check"] = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return p.performHealthCheck(ctx)
 	}
 
+	// Register built-in introspection function
+	p.functions["__introspect"] = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return p.introspect(), nil
+	}
+
+	// Register built-in capability negotiation function
+	p.functions["__capabilities"] = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return p.Capabilities().toMap(), nil
+	}
+
+	// Register built-in connectivity check that echoes its input
+	p.functions["__ping"] = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return p.ping(args), nil
+	}
+
 	// Set the global plugin instance for resolver access
 	currentPlugin = p
 
 	return p
 }
 
+// Name returns the plugin name passed to Init
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// Version returns the plugin version passed to Init
+func (p *Plugin) Version() string {
+	return p.version
+}
+
+// HasAPIKey reports whether the plugin was initialized with a non-empty API key
+func (p *Plugin) HasAPIKey() bool {
+	return p.apiKey != ""
+}
+
 // RegisterQuery registers a GraphQL query
 func (p *Plugin) RegisterQuery(name string, field GraphQLField, resolver ResolverFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if resolver == nil {
+		log.Printf("SDK Warning: query '%s' registered with a nil resolver", name)
+	}
+	p.registerQuery(name, field, resolver)
+}
+
+// registerQuery adds a query to the registry. Callers must hold the registry write lock.
+func (p *Plugin) registerQuery(name string, field GraphQLField, resolver ResolverFunc) {
 	field.Resolve = name + "Resolver"
+	p.schemaCache = nil
+	if _, exists := p.queries[name]; !exists {
+		p.queryOrder = append(p.queryOrder, name)
+	}
 	p.queries[name] = field
 	p.resolvers[name] = resolver
-
 }
 
 // RegisterMutation registers a GraphQL mutation
 func (p *Plugin) RegisterMutation(name string, field GraphQLField, resolver ResolverFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if resolver == nil {
+		log.Printf("SDK Warning: mutation '%s' registered with a nil resolver", name)
+	}
+	p.registerMutation(name, field, resolver)
+}
+
+// registerMutation adds a mutation to the registry. Callers must hold the registry write lock.
+func (p *Plugin) registerMutation(name string, field GraphQLField, resolver ResolverFunc) {
 	field.Resolve = name + "Resolver"
+	p.schemaCache = nil
+	if _, exists := p.mutations[name]; !exists {
+		p.mutationOrder = append(p.mutationOrder, name)
+	}
 	p.mutations[name] = field
 	p.resolvers[name] = resolver
-
 }
 
 // RegisterQueries registers multiple GraphQL queries at once
@@ -357,9 +717,37 @@ func (p *Plugin) RegisterMutations(mutations map[string]GraphQLField, resolvers
 // RegisterRESTAPI registers a REST API endpoint
 func (p *Plugin) RegisterRESTAPI(endpoint RESTEndpoint, handler RESTHandlerFunc) {
 	endpoint.Handler = endpoint.Method + "_" + endpoint.Path
+
+	// Serve rejects an unconvertible schema via Validate; warn now so the log points at the registration
+	if err := validateRESTSchema(endpoint.Schema); err != nil {
+		log.Printf("SDK Warning: REST endpoint %s %s has an invalid schema: %v", endpoint.Method, endpoint.Path, err)
+	}
+	if handler == nil {
+		log.Printf("SDK Warning: REST endpoint %s %s registered with a nil handler", endpoint.Method, endpoint.Path)
+	}
+
+	p.mu.Lock()
+	p.registerRESTAPI(endpoint, handler)
+	p.mu.Unlock()
+
+	log.Printf("Plugin SDK: Registered REST API %s %s", endpoint.Method, endpoint.Path)
+}
+
+// registerRESTAPI adds an endpoint whose Handler key is set to the registry.
+// Callers must hold the registry write lock.
+func (p *Plugin) registerRESTAPI(endpoint RESTEndpoint, handler RESTHandlerFunc) {
 	p.restAPIs = append(p.restAPIs, endpoint)
 	p.restHandlers[endpoint.Handler] = handler
-	log.Printf("Plugin SDK: Registered REST API %s %s", endpoint.Method, endpoint.Path)
+}
+
+// RegisterRESTAPIMethods registers one handler for the same endpoint under several HTTP methods,
+// e.g. GET and HEAD, or PUT and PATCH. endpoint.Method is ignored.
+func (p *Plugin) RegisterRESTAPIMethods(methods []string, endpoint RESTEndpoint, handler RESTHandlerFunc) {
+	for _, method := range methods {
+		methodEndpoint := endpoint
+		methodEndpoint.Method = strings.ToUpper(method)
+		p.RegisterRESTAPI(methodEndpoint, handler)
+	}
 }
 
 // RegisterRESTAPIs registers multiple REST API endpoints at once
@@ -374,6 +762,12 @@ func (p *Plugin) RegisterRESTAPIs(endpoints []RESTEndpoint, handlers map[string]
 
 // RegisterFunction registers a custom function
 func (p *Plugin) RegisterFunction(name string, function FunctionHandlerFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if function == nil {
+		log.Printf("SDK Warning: function '%s' registered with a nil handler", name)
+	}
 	p.functions[name] = function
 
 }
@@ -387,58 +781,213 @@ func (p *Plugin) RegisterFunctions(functions map[string]FunctionHandlerFunc) {
 
 // GetQueryField returns the field definition for a query
 func (p *Plugin) GetQueryField(name string) (GraphQLField, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	field, exists := p.queries[name]
 	return field, exists
 }
 
 // GetMutationField returns the field definition for a mutation
 func (p *Plugin) GetMutationField(name string) (GraphQLField, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	field, exists := p.mutations[name]
 	return field, exists
 }
 
 // RegisterObjectType registers an object type definition for nested object support
 func (p *Plugin) RegisterObjectType(objectType ObjectTypeDefinition) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.schemaCache = nil
+	if _, exists := p.objectTypes[objectType.TypeName]; !exists {
+		p.objectTypeOrder = append(p.objectTypeOrder, objectType.TypeName)
+	}
 	p.objectTypes[objectType.TypeName] = objectType
 
 }
 
 // GetObjectType returns the object type definition for a given name
 func (p *Plugin) GetObjectType(name string) (ObjectTypeDefinition, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	objectType, exists := p.objectTypes[name]
 	return objectType, exists
 }
 
-// GetAllObjectTypes returns all registered object types
+// GetAllObjectTypes returns a snapshot of all registered object types
 func (p *Plugin) GetAllObjectTypes() map[string]ObjectTypeDefinition {
-	return p.objectTypes
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	objectTypes := make(map[string]ObjectTypeDefinition, len(p.objectTypes))
+	for name, objectType := range p.objectTypes {
+		objectTypes[name] = objectType
+	}
+	return objectTypes
+}
+
+// ResolverNames returns the names of all registered queries and mutations, sorted
+func (p *Plugin) ResolverNames() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.resolvers))
+	for name := range p.resolvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RESTRoutes returns copies of all registered REST endpoints, sorted by path and then method.
+// Schemas are deep-copied, so modifying the result doesn't affect the plugin.
+func (p *Plugin) RESTRoutes() []RESTEndpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	routes := make([]RESTEndpoint, len(p.restAPIs))
+	for i, endpoint := range p.restAPIs {
+		if endpoint.Schema != nil {
+			endpoint.Schema = deepCopyValue(endpoint.Schema, make(map[uintptr]interface{})).(map[string]interface{})
+		}
+		routes[i] = endpoint
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// FunctionNames returns the names of all registered custom functions, sorted
+func (p *Plugin) FunctionNames() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.functions))
+	for name := range p.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-// Serve starts the plugin server
+// Serve starts the plugin server and blocks until the host stops it
 func (p *Plugin) Serve() {
+	if err := p.ServeContext(context.Background()); err != nil {
+		log.Fatalf("Plugin SDK: %v", err)
+	}
+}
+
+// DefaultGracefulStopTimeout bounds how long ServeContext lets in-flight calls finish after
+// ctx is cancelled before it forces the server to stop
+const DefaultGracefulStopTimeout = 10 * time.Second
+
+// ServeContext starts the plugin server and blocks until the host stops it or ctx is cancelled,
+// then runs the OnShutdown hooks. On cancellation the server stops accepting calls and in-flight
+// Execute calls get DefaultGracefulStopTimeout to finish; ServeContext returns only once the
+// server has stopped. It returns an error only if the plugin definition is invalid.
+func (p *Plugin) ServeContext(ctx context.Context) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
 
 	handshakeConfig := hcplugin.HandshakeConfig{
-		ProtocolVersion:  1,
-		MagicCookieKey:   "APITO_PLUGIN",
-		MagicCookieValue: "apito_plugin_magic_cookie_v1",
+		ProtocolVersion:  ProtocolVersion,
+		MagicCookieKey:   MagicCookieKey,
+		MagicCookieValue: MagicCookieValue,
 	}
 
 	pluginMap := map[string]hcplugin.Plugin{
 		"Plugin": &grpcPlugin{Impl: p.impl},
 	}
 
-	logger := hclog.New(&hclog.LoggerOptions{
-		Name:   p.name,
-		Output: os.Stderr,
-		Level:  hclog.Error, // Only show errors
-	})
+	p.mu.RLock()
+	logger := p.logger
+	p.mu.RUnlock()
+	if logger == nil {
+		logger = hclog.New(&hclog.LoggerOptions{
+			Name:   p.name,
+			Output: os.Stderr,
+			Level:  hclog.Error, // Only show errors
+		})
+	}
+
+	// Keep hold of the gRPC server so cancellation can stop it. A cancellation that arrives
+	// before the server exists is recorded, and the server is stopped as soon as it's created,
+	// which makes its Serve return immediately.
+	var serverMu sync.Mutex
+	var server *grpc.Server
+	var cancelled bool
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hcplugin.Serve(&hcplugin.ServeConfig{
+			HandshakeConfig: handshakeConfig,
+			Plugins:         pluginMap,
+			GRPCServer: func(opts []grpc.ServerOption) *grpc.Server {
+				serverMu.Lock()
+				defer serverMu.Unlock()
+				server = grpc.NewServer(append(opts, p.GRPCServerOptions()...)...)
+				if cancelled {
+					server.Stop()
+				}
+				return server
+			},
+			Logger: logger,
+		})
+	}()
+
+	select {
+	case <-ctx.Done():
+		p.BeginDraining()
+		serverMu.Lock()
+		cancelled = true
+		stopping := server
+		serverMu.Unlock()
+		if stopping != nil {
+			gracefulStop(stopping, DefaultGracefulStopTimeout)
+		}
+		<-done
+	case <-done:
+	}
 
-	hcplugin.Serve(&hcplugin.ServeConfig{
-		HandshakeConfig: handshakeConfig,
-		Plugins:         pluginMap,
-		GRPCServer:      hcplugin.DefaultGRPCServer,
-		Logger:          logger,
-	})
+	p.runShutdownHooks()
+	return nil
+}
+
+// gracefulStop stops server after its in-flight calls finish, forcing it to stop when they
+// take longer than timeout
+func gracefulStop(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Printf("SDK Warning: in-flight calls did not finish within %v; stopping the server", timeout)
+		server.Stop()
+		<-stopped
+	}
+}
+
+// GRPCServer returns the gRPC service that Serve exposes, for hosting the plugin in-process
+// (see the testutil package)
+func (p *Plugin) GRPCServer() protobuff.PluginServiceServer {
+	return p.impl
 }
 
 // grpcPlugin implements the hcplugin.GRPCPlugin interface
@@ -460,8 +1009,24 @@ func (p *grpcPlugin) GRPCClient(ctx context.Context, broker *hcplugin.GRPCBroker
 
 func (impl *pluginImpl) Init(ctx context.Context, req *protobuff.InitRequest) (*protobuff.InitResponse, error) {
 	// Set environment variables
-	for _, env := range req.EnvVars {
-		os.Setenv(env.Key, env.Value)
+	env := make(map[string]string, len(req.EnvVars))
+	for _, envVar := range req.EnvVars {
+		os.Setenv(envVar.Key, envVar.Value)
+		env[envVar.Key] = envVar.Value
+	}
+
+	// Run plugin init hooks with the freshly applied environment, failing fast on the first error
+	impl.plugin.mu.RLock()
+	initHooks := append([]InitHookFunc(nil), impl.plugin.initHooks...)
+	impl.plugin.mu.RUnlock()
+
+	for i, hook := range initHooks {
+		if err := hook(ctx, env); err != nil {
+			return &protobuff.InitResponse{
+				Success: false,
+				Message: fmt.Sprintf("Plugin '%s' init hook %d failed: %v", impl.plugin.name, i, err),
+			}, nil
+		}
 	}
 
 	return &protobuff.InitResponse{
@@ -471,31 +1036,87 @@ func (impl *pluginImpl) Init(ctx context.Context, req *protobuff.InitRequest) (*
 }
 
 func (impl *pluginImpl) Migration(ctx context.Context, req *protobuff.MigrationRequest) (*protobuff.MigrationResponse, error) {
+	impl.plugin.mu.RLock()
+	steps := impl.plugin.pendingMigrations(os.Getenv(MigrationFromVersionEnv))
+	impl.plugin.mu.RUnlock()
+
+	if len(steps) == 0 {
+		return &protobuff.MigrationResponse{
+			Success: true,
+			Message: fmt.Sprintf("No migration needed for plugin '%s'", impl.plugin.name),
+		}, nil
+	}
+
+	ran := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if err := step.fn(ctx); err != nil {
+			return &protobuff.MigrationResponse{
+				Success: false,
+				Message: fmt.Sprintf("Migration %s failed for plugin '%s': %v (completed: [%s])", step.name(), impl.plugin.name, err, strings.Join(ran, ", ")),
+			}, nil
+		}
+		ran = append(ran, step.name())
+	}
+
 	return &protobuff.MigrationResponse{
 		Success: true,
-		Message: fmt.Sprintf("No migration needed for plugin '%s'", impl.plugin.name),
+		Message: fmt.Sprintf("Ran %d migration step(s) for plugin '%s': [%s]", len(ran), impl.plugin.name, strings.Join(ran, ", ")),
 	}, nil
 }
 
 func (impl *pluginImpl) SchemaRegister(ctx context.Context, req *protobuff.SchemaRegisterRequest) (*protobuff.SchemaRegisterResponse, error) {
+	schema, err := impl.cachedSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	impl.plugin.schemaDirty.Store(false)
+	log.Printf("Plugin SDK: GraphQL schema registered successfully for plugin '%s'", impl.plugin.name)
+	return &protobuff.SchemaRegisterResponse{
+		Schema: schema,
+	}, nil
+}
+
+// cachedSchema returns the serialized GraphQL schema, building it on first use.
+// The cache is cleared whenever the registries change.
+func (impl *pluginImpl) cachedSchema() (*protobuff.ThirdPartyGraphQLSchemas, error) {
+	impl.plugin.mu.RLock()
+	schema := impl.plugin.schemaCache
+	impl.plugin.mu.RUnlock()
+	if schema != nil {
+		return schema, nil
+	}
+
+	impl.plugin.mu.Lock()
+	defer impl.plugin.mu.Unlock()
 
-	// Convert queries to protobuf struct
-	queriesMap := make(map[string]interface{})
-	for name, field := range impl.plugin.queries {
-		queriesMap[name] = impl.serializeGraphQLField(field)
+	// Another caller may have built it while we waited for the lock
+	if impl.plugin.schemaCache != nil {
+		return impl.plugin.schemaCache, nil
 	}
 
-	// Convert mutations to protobuf struct
-	mutationsMap := make(map[string]interface{})
-	for name, field := range impl.plugin.mutations {
-		mutationsMap[name] = impl.serializeGraphQLField(field)
+	schema, err := impl.buildSchema()
+	if err != nil {
+		return nil, err
 	}
+	impl.plugin.schemaCache = schema
+	return schema, nil
+}
+
+// buildSchema serializes the schema model's queries, mutations, object and enum types.
+// Callers must hold the registry lock.
+func (impl *pluginImpl) buildSchema() (*protobuff.ThirdPartyGraphQLSchemas, error) {
+	model := impl.plugin.schemaModel()
+
+	queriesMap := impl.serializeSchemaFields(model.Queries)
+	mutationsMap := impl.serializeSchemaFields(model.Mutations)
 
 	// Convert object types to protobuf struct
 	objectTypesMap := make(map[string]interface{})
-	for name, objectType := range impl.plugin.objectTypes {
+	for position, objectType := range model.ObjectTypes {
 		serialized := impl.serializeObjectTypeDefinition(objectType)
-		objectTypesMap[name] = serialized
+		serialized["position"] = position
+		objectTypesMap[impl.plugin.namespacedTypeName(objectType.TypeName)] = serialized
 		//log.Printf("[NESTED-OBJECT-DEBUG] [SDK] Serializing object type %s: %+v", name, serialized)
 	}
 
@@ -509,14 +1130,23 @@ func (impl *pluginImpl) SchemaRegister(ctx context.Context, req *protobuff.Schem
 		return nil, fmt.Errorf("failed to create mutations struct: %v", err)
 	}
 
+	// Convert enum types referenced by object fields
+	enumTypesMap := make(map[string]interface{}, len(model.EnumTypes))
+	for _, enumType := range model.EnumTypes {
+		enumTypesMap[impl.plugin.namespacedTypeName(enumType.TypeName)] = impl.serializeEnumTypeDefinition(enumType)
+	}
+
 	// For now, include object types in a custom field or extend the existing schema
 	// We'll add object types as a special query field that the engine can recognize
-	if len(objectTypesMap) > 0 {
+	if len(objectTypesMap) > 0 || len(enumTypesMap) > 0 {
 		objectTypesField := map[string]interface{}{
 			"type":        "String",
 			"description": "Object type definitions for nested objects",
 			"objectTypes": objectTypesMap,
 		}
+		if len(enumTypesMap) > 0 {
+			objectTypesField["enumTypes"] = enumTypesMap
+		}
 		queriesMap["__objectTypes"] = objectTypesField
 		//log.Printf("[NESTED-OBJECT-DEBUG] [SDK] Adding __objectTypes field with %d types: %+v", len(objectTypesMap), objectTypesField)
 
@@ -527,14 +1157,9 @@ func (impl *pluginImpl) SchemaRegister(ctx context.Context, req *protobuff.Schem
 		}
 	}
 
-	schema := &protobuff.ThirdPartyGraphQLSchemas{
+	return &protobuff.ThirdPartyGraphQLSchemas{
 		Queries:   queriesStruct,
 		Mutations: mutationsStruct,
-	}
-
-	log.Printf("Plugin SDK: GraphQL schema registered successfully for plugin '%s'", impl.plugin.name)
-	return &protobuff.SchemaRegisterResponse{
-		Schema: schema,
 	}, nil
 }
 
@@ -547,8 +1172,33 @@ func (impl *pluginImpl) serializeGraphQLField(field GraphQLField) map[string]int
 	}
 
 	// Serialize arguments if they exist
-	if len(field.Args) > 0 {
-		result["args"] = impl.serializeArgs(field.Args)
+	if len(field.Args) > 0 && impl.plugin.structuredArgs() {
+		args, objectType := impl.serializeArgDefinitions(field.Args)
+		result["args"] = args
+		result["argFormat"] = ArgFormatStructured
+		if objectType != nil {
+			result["objectType"] = objectType
+		}
+	} else if len(field.Args) > 0 {
+		result["args"] = impl.serializeArgs(impl.legacyArgs(field.Args))
+	}
+
+	// Let the host apply rate limiting before calling the resolver
+	if field.RateLimit > 0 {
+		result["rateLimit"] = map[string]interface{}{
+			"requestsPerMinute": field.RateLimit,
+		}
+	}
+
+	// Surface deprecation so introspection shows the @deprecated directive
+	if field.IsDeprecated {
+		result["deprecated"] = true
+		result["deprecationReason"] = field.DeprecationReason
+	}
+
+	// Only reaches the host through introspection, since buildSchema skips internal fields
+	if field.Internal {
+		result["internal"] = true
 	}
 
 	return result
@@ -581,6 +1231,9 @@ func (impl *pluginImpl) serializeTypeDefinition(typeDef GraphQLTypeDefinition) m
 
 	if typeDef.Name != "" {
 		result["name"] = typeDef.Name
+		if typeDef.Kind != "scalar" {
+			result["name"] = impl.plugin.namespacedTypeName(typeDef.Name)
+		}
 	}
 
 	if typeDef.ScalarType != "" {
@@ -598,26 +1251,35 @@ func (impl *pluginImpl) serializeTypeDefinition(typeDef GraphQLTypeDefinition) m
 	return result
 }
 
-// serializeObjectTypeDefinition converts an ObjectTypeDefinition to protobuf-compatible format
+// serializeObjectTypeDefinition converts an ObjectTypeDefinition to protobuf-compatible format.
+// Non-scalar fields are emitted as named references, never inlined, so self- and
+// mutually-referential object types serialize without recursion.
 func (impl *pluginImpl) serializeObjectTypeDefinition(objectType ObjectTypeDefinition) map[string]interface{} {
 	// Convert ObjectFieldDef to the engine's expected format
+	// Structs are maps, so each field carries its declaration "position" like queries do
 	engineFields := make(map[string]interface{})
-	for fieldName, fieldDef := range objectType.Fields {
+	for position, fieldName := range objectType.OrderedFieldNames() {
+		fieldDef := objectType.Fields[fieldName]
 		// Convert ObjectFieldDef to engine's GraphQL field format
 		var fieldType map[string]interface{}
 
 		// Start with the base type
-		if impl.isScalarType(fieldDef.Type) {
+		if fieldDef.Enum {
+			fieldType = map[string]interface{}{
+				"kind": "enum",
+				"name": impl.plugin.namespacedTypeName(fieldDef.Type),
+			}
+		} else if impl.isScalarType(fieldDef.Type) {
 			fieldType = map[string]interface{}{
 				"kind":       "scalar",
 				"name":       fieldDef.Type,
 				"scalarType": fieldDef.Type,
 			}
 		} else {
-			// For object types, create a reference
+			// For object types, create a reference by name (supports circular types)
 			fieldType = map[string]interface{}{
 				"kind": "object",
-				"name": fieldDef.Type,
+				"name": impl.plugin.namespacedTypeName(fieldDef.Type),
 			}
 		}
 
@@ -647,15 +1309,25 @@ func (impl *pluginImpl) serializeObjectTypeDefinition(objectType ObjectTypeDefin
 			}
 		}
 
-		engineFields[fieldName] = map[string]interface{}{
+		engineField := map[string]interface{}{
 			"type":        fieldType,
 			"description": fieldDef.Description,
+			"position":    position,
 		}
+		if fieldDef.IsDeprecated {
+			engineField["deprecated"] = true
+			engineField["deprecationReason"] = fieldDef.DeprecationReason
+		}
+		// Tell the host which function to execute for fields with their own resolver
+		if fieldDef.Resolved {
+			engineField["resolve"] = FieldResolverName(objectType.TypeName, fieldName)
+		}
+		engineFields[fieldName] = engineField
 	}
 
 	return map[string]interface{}{
 		"kind":        "object",
-		"name":        objectType.TypeName,
+		"name":        impl.plugin.namespacedTypeName(objectType.TypeName),
 		"description": objectType.Description,
 		"fields":      engineFields,
 	}
@@ -664,7 +1336,7 @@ func (impl *pluginImpl) serializeObjectTypeDefinition(objectType ObjectTypeDefin
 // isScalarType checks if a type is a GraphQL scalar type
 func (impl *pluginImpl) isScalarType(typeName string) bool {
 	switch typeName {
-	case "String", "Int", "Boolean", "Float", "ID":
+	case "String", "Int", "Boolean", "Float", "ID", "DateTime":
 		return true
 	default:
 		return false
@@ -676,11 +1348,13 @@ func (impl *pluginImpl) serializeObjectFields(fields map[string]ObjectFieldDef)
 	result := make(map[string]interface{})
 	for fieldName, fieldDef := range fields {
 		result[fieldName] = map[string]interface{}{
-			"type":          fieldDef.Type,
-			"description":   fieldDef.Description,
-			"nullable":      fieldDef.Nullable,
-			"list":          fieldDef.List,
-			"listOfNonNull": fieldDef.ListOfNonNull,
+			"type":              fieldDef.Type,
+			"description":       fieldDef.Description,
+			"nullable":          fieldDef.Nullable,
+			"list":              fieldDef.List,
+			"listOfNonNull":     fieldDef.ListOfNonNull,
+			"deprecated":        fieldDef.IsDeprecated,
+			"deprecationReason": fieldDef.DeprecationReason,
 		}
 	}
 	return result
@@ -733,6 +1407,9 @@ func (impl *pluginImpl) serializeValue(value interface{}) interface{} {
 func (impl *pluginImpl) RESTApiRegister(ctx context.Context, req *protobuff.RESTApiRegisterRequest) (*protobuff.RESTApiRegisterResponse, error) {
 	log.Printf("Plugin SDK: Registering REST APIs for plugin '%s'...", impl.plugin.name)
 
+	impl.plugin.mu.RLock()
+	defer impl.plugin.mu.RUnlock()
+
 	apis := make([]*protobuff.ThirdPartyRESTApi, len(impl.plugin.restAPIs))
 	for i, endpoint := range impl.plugin.restAPIs {
 		schema, err := structpb.NewStruct(endpoint.Schema)
@@ -799,8 +1476,57 @@ func isComplexArrayData(data interface{}) bool {
 	return false
 }
 
-// serializeComplexData serializes complex data as JSON bytes wrapped in anypb.Any
-func serializeComplexData(data interface{}, functionName, functionType string) (*anypb.Any, error) {
+// primitiveResultStruct builds the result struct directly for nil, string, bool and numeric results.
+// It reports false for anything else so the caller falls back to the generic path.
+func primitiveResultStruct(data interface{}, functionName, functionType string) (*structpb.Struct, bool) {
+	var value *structpb.Value
+	switch v := data.(type) {
+	case nil:
+		value = structpb.NewNullValue()
+	case string:
+		value = structpb.NewStringValue(v)
+	case bool:
+		value = structpb.NewBoolValue(v)
+	case int:
+		value = structpb.NewNumberValue(float64(v))
+	case int32:
+		value = structpb.NewNumberValue(float64(v))
+	case int64:
+		value = structpb.NewNumberValue(float64(v))
+	case uint:
+		value = structpb.NewNumberValue(float64(v))
+	case uint32:
+		value = structpb.NewNumberValue(float64(v))
+	case uint64:
+		value = structpb.NewNumberValue(float64(v))
+	case float32:
+		value = structpb.NewNumberValue(float64(v))
+	case float64:
+		value = structpb.NewNumberValue(v)
+	default:
+		return nil, false
+	}
+
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"data":          value,
+			"function_name": structpb.NewStringValue(functionName),
+			"function_type": structpb.NewStringValue(functionType),
+		},
+	}, true
+}
+
+// jsonBufferPool reuses buffers across concurrent serializeComplexData calls
+var jsonBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// serializeComplexData serializes complex data as JSON bytes wrapped in anypb.Any.
+// A 413 CodedError is returned when the JSON exceeds maxBytes (0 disables the check).
+// extras (response headers, "__debug") are added to the top level of the result.
+func serializeComplexData(data interface{}, functionName, functionType string, maxBytes int64, extras map[string]interface{}) (*anypb.Any, error) {
 	// Create the result map
 	resultMap := map[string]interface{}{
 		"data":          data,
@@ -808,17 +1534,26 @@ func serializeComplexData(data interface{}, functionName, functionType string) (
 		"function_type": functionType,
 		"serialization": "json_bytes", // Flag to indicate this is JSON serialized
 	}
+	for key, extra := range extras {
+		resultMap[key] = extra
+	}
 
-	// JSON serialize the entire result
-	jsonBytes, err := json.Marshal(resultMap)
-	if err != nil {
+	// JSON serialize the entire result into a pooled buffer
+	buf := jsonBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer jsonBufferPool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(resultMap); err != nil {
 		return nil, fmt.Errorf("failed to JSON marshal complex data: %v", err)
 	}
+	if maxBytes > 0 && int64(buf.Len()) > maxBytes {
+		return nil, PayloadTooLargeError("Response too large", fmt.Sprintf("response is %d bytes, limit is %d bytes", buf.Len(), maxBytes))
+	}
 
-	// Pack JSON bytes as anypb.Any with type indication
+	// Pack JSON bytes as anypb.Any with type indication (Encode appends a newline)
 	anyResult, err := anypb.New(&structpb.Value{
 		Kind: &structpb.Value_StringValue{
-			StringValue: string(jsonBytes),
+			StringValue: string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))),
 		},
 	})
 	if err != nil {
@@ -828,6 +1563,114 @@ func serializeComplexData(data interface{}, functionName, functionType string) (
 	return anyResult, nil
 }
 
+// lookupResolver finds a GraphQL resolver by name
+func (p *Plugin) lookupResolver(name string) (ResolverFunc, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	resolver, exists := p.resolvers[name]
+	return resolver, exists
+}
+
+// lookupField finds the query or mutation definition for a resolver
+func (p *Plugin) lookupField(name string) (GraphQLField, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if field, exists := p.queries[name]; exists {
+		return field, true
+	}
+	field, exists := p.mutations[name]
+	return field, exists
+}
+
+// lookupFunction finds a custom function by name
+func (p *Plugin) lookupFunction(name string) (FunctionHandlerFunc, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	function, exists := p.functions[name]
+	return function, exists
+}
+
+// lookupRESTHandler finds a REST handler by function name, accepting both the
+// registered format (METHOD_path) and the engine format (rest_method_path).
+// It also returns the registered handler key.
+func (p *Plugin) lookupRESTHandler(functionName string) (RESTHandlerFunc, string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Try to find the handler using the function name directly first
+	if handler, exists := p.restHandlers[functionName]; exists {
+		return handler, functionName, true
+	}
+
+	// If not found, try to convert from new format (rest_method_path) to old format (METHOD_path)
+	if !strings.HasPrefix(functionName, "rest_") {
+		return nil, "", false
+	}
+
+	// Convert from "rest_get_hello" to "GET_/hello"
+	// Or from "rest_post_users_:id" to "POST_/users/:id"
+	parts := strings.SplitN(functionName, "_", 3) // Split into ["rest", "method", "path"]
+	if len(parts) < 3 {
+		return nil, "", false
+	}
+
+	method := strings.ToUpper(parts[1])
+	pathParts := strings.Split(parts[2], "_")
+
+	// Reconstruct the path with slashes
+	var path strings.Builder
+	path.WriteString("/")
+	for i, part := range pathParts {
+		if i > 0 {
+			path.WriteString("/")
+		}
+		path.WriteString(part)
+	}
+
+	handlerKey := method + "_" + path.String()
+	handler, exists := p.restHandlers[handlerKey]
+	return handler, handlerKey, exists
+}
+
+// checkRequestSize returns a 413 CodedError when a REST request's arguments exceed the
+// endpoint's limit, or the plugin-wide limit when the endpoint doesn't override it
+func (p *Plugin) checkRequestSize(handlerKey string, req *protobuff.ExecuteRequest) error {
+	limit := p.maxRequestBytes.Load()
+
+	p.mu.RLock()
+	for _, endpoint := range p.restAPIs {
+		if endpoint.Handler == handlerKey && endpoint.MaxRequestBytes != 0 {
+			limit = endpoint.MaxRequestBytes
+			break
+		}
+	}
+	p.mu.RUnlock()
+
+	if limit <= 0 || req.Args == nil {
+		return nil
+	}
+	if size := proto.Size(req.Args); int64(size) > limit {
+		return PayloadTooLargeError("Request too large", fmt.Sprintf("request is %d bytes, limit is %d bytes", size, limit))
+	}
+	return nil
+}
+
+// SetStrictArgs makes GraphQL queries and mutations fail with a BadRequestError when the client
+// sends an argument the field doesn't declare, instead of silently ignoring it. Keys the host
+// injects with a context_, query_, body_ or path_ prefix are always allowed.
+func (p *Plugin) SetStrictArgs(strict bool) {
+	p.strictArgs.Store(strict)
+}
+
+// SetMaxRequestBytes sets the largest REST request payload the plugin will process.
+// It is disabled (0) by default; endpoints can override it with WithMaxRequestBytes.
+func (p *Plugin) SetMaxRequestBytes(n int64) {
+	p.maxRequestBytes.Store(n)
+}
+
 func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteRequest) (*protobuff.ExecuteResponse, error) {
 
 	// Extract arguments from the request
@@ -838,8 +1681,9 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 
 	// Extract context data and merge it with arguments
 	// This allows plugins to access sensitive data passed from the host
+	var contextData map[string]interface{}
 	if req.Context != nil {
-		contextData := req.Context.AsMap()
+		contextData = req.Context.AsMap()
 
 		// Add context data to args with a "context_" prefix to avoid conflicts
 		for key, value := range contextData {
@@ -853,14 +1697,71 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 		}
 	}
 
+	// Expose the plugin's own metadata to resolvers
+	ctx = context.WithValue(ctx, "plugin_name", impl.plugin.name)
+	ctx = context.WithValue(ctx, "plugin_version", impl.plugin.version)
+	ctx = withRequestInfo(ctx, req, contextData)
+	ctx = withRequestValues(ctx)
+
+	// Propagate the correlation ID to args-based helpers (GetContextString(args, "request_id"))
+	if _, exists := args["context_request_id"]; !exists {
+		args["context_request_id"] = GetRequestID(ctx)
+	}
+
+	// CORS headers for REST responses, nil when CORS is disabled or the origin isn't allowed
+	var headers map[string]interface{}
+	if req.FunctionType == "rest_api" {
+		headers = impl.plugin.corsHeaders(args, false)
+	}
+
+	// Validate the inputs without side effects when the host only asks for a dry run
+	if resolverName, ok := dryRunTarget(req, args); ok {
+		return impl.dryRunResponse(req, resolverName, args), nil
+	}
+
+	// Bound the handler by the host deadline and the execution timeout
+	ctx, cancel := impl.plugin.withExecutionDeadline(ctx, args)
+	defer cancel()
+
+	// Lifecycle hooks wrap the dispatch below; a panicking handler still reaches AfterExecute
+	ctx = impl.plugin.runBeforeExecute(ctx)
+	dispatched := false
+	defer func() {
+		if dispatched {
+			return
+		}
+		if r := recover(); r != nil {
+			impl.plugin.runAfterExecute(ctx, nil, fmt.Errorf("panic in %s: %v", req.FunctionName, r))
+			panic(r)
+		}
+	}()
+
 	var result interface{}
 	var err error
+	startTime := time.Now()
 
 	// Handle different function types
 	switch req.FunctionType {
 	case "graphql_query", "graphql_mutation":
-		if resolver, exists := impl.plugin.resolvers[req.FunctionName]; exists {
-			result, err = resolver(ctx, args)
+		if resolver, exists := impl.plugin.lookupResolver(req.FunctionName); exists {
+			if field, hasField := impl.plugin.lookupField(req.FunctionName); hasField {
+				// Transformers run after coercion and before validation, and the resolver
+				// receives the transformed values
+				parser := impl.plugin.newArgParser(field)
+				args = parser.transformArgs(args)
+				err = parser.CheckConstraints(args)
+				if err == nil && impl.plugin.strictArgs.Load() {
+					err = parser.CheckUnknownArgs(args)
+				}
+			}
+			if err == nil {
+				result, err = impl.plugin.runIdempotent(req, args, func() (interface{}, error) {
+					return resolver(ctx, args)
+				})
+			}
+		} else if fieldResolver, exists := impl.plugin.lookupFieldResolver(req.FunctionName); exists {
+			// Object type field resolvers receive the parent object as the "parent" context value
+			result, err = fieldResolver(ctx, args)
 		} else {
 			return &protobuff.ExecuteResponse{
 				Success: false,
@@ -869,39 +1770,15 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 		}
 
 	case "rest_api":
-		// Try to find the handler using the function name directly first
-		handler, exists := impl.plugin.restHandlers[req.FunctionName]
-
-		// If not found, try to convert from new format (rest_method_path) to old format (METHOD_path)
-		if !exists && strings.HasPrefix(req.FunctionName, "rest_") {
-			// Convert from "rest_get_hello" to "GET_/hello"
-			// Or from "rest_post_users_:id" to "POST_/users/:id"
-			parts := strings.SplitN(req.FunctionName, "_", 3) // Split into ["rest", "method", "path"]
-			if len(parts) >= 3 {
-				method := strings.ToUpper(parts[1])
-				pathParts := strings.Split(parts[2], "_")
-
-				// Reconstruct the path with slashes
-				var path strings.Builder
-				path.WriteString("/")
-				for i, part := range pathParts {
-					if i > 0 {
-						path.WriteString("/")
-					}
-					path.WriteString(part)
-				}
-
-				oldFormatKey := method + "_" + path.String()
-
-				if h, found := impl.plugin.restHandlers[oldFormatKey]; found {
-					handler = h
-					exists = true
-				}
+		if handler, handlerKey, exists := impl.plugin.lookupRESTHandler(req.FunctionName); exists {
+			if sizeErr := impl.plugin.checkRequestSize(handlerKey, req); sizeErr != nil {
+				return impl.errorResponse(ctx, req, sizeErr, headers), nil
 			}
-		}
-
-		if exists {
-			result, err = handler(ctx, args)
+			result, err = impl.plugin.runIdempotent(req, args, func() (interface{}, error) {
+				return handler(ctx, args)
+			})
+		} else if preflight, ok := impl.corsPreflightResponse(ctx, req, args); ok {
+			return preflight, nil
 		} else {
 			return &protobuff.ExecuteResponse{
 				Success: false,
@@ -910,7 +1787,7 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 		}
 
 	case "function", "system":
-		if function, exists := impl.plugin.functions[req.FunctionName]; exists {
+		if function, exists := impl.plugin.lookupFunction(req.FunctionName); exists {
 			result, err = function(ctx, args)
 		} else {
 			return &protobuff.ExecuteResponse{
@@ -925,6 +1802,17 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 			Message: fmt.Sprintf("Unsupported function type: %s", req.FunctionType),
 		}, nil
 	}
+	duration := time.Since(startTime)
+	err = impl.plugin.mapError(err)
+	err = deadlineError(err)
+	err = impl.plugin.localizeError(err, GetLocale(args))
+	if err == nil && (req.FunctionType == "graphql_query" || req.FunctionType == "graphql_mutation") {
+		result = impl.plugin.maskResultFields(req.FunctionName, result, GetRoles(args))
+	}
+	impl.plugin.metrics.record(req.FunctionType, req.FunctionName, duration, err != nil)
+	dispatched = true
+	impl.plugin.runAfterExecute(ctx, result, err)
+	impl.plugin.audit(ctx, req, args, result, duration, err)
 
 	if err != nil {
 		// Handle GraphQL errors differently from REST/function errors
@@ -933,37 +1821,7 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 				// Return GraphQL error as structured data
 				gqlErr := GetGraphQLError(err)
 
-				// Create the error object with safe serialization
-				errorObj := map[string]interface{}{
-					"message": gqlErr.Message,
-				}
-
-				// Add extensions if they exist
-				if gqlErr.Extensions != nil && len(gqlErr.Extensions) > 0 {
-					errorObj["extensions"] = gqlErr.Extensions
-				}
-
-				// Add path if it exists and is not empty
-				if gqlErr.Path != nil && len(gqlErr.Path) > 0 {
-					// Convert path to string array for safe serialization
-					pathStrings := make([]string, len(gqlErr.Path))
-					for i, p := range gqlErr.Path {
-						pathStrings[i] = fmt.Sprintf("%v", p)
-					}
-					errorObj["path"] = pathStrings
-				}
-
-				// Add locations if they exist and are not empty
-				if gqlErr.Locations != nil && len(gqlErr.Locations) > 0 {
-					locations := make([]map[string]interface{}, len(gqlErr.Locations))
-					for i, loc := range gqlErr.Locations {
-						locations[i] = map[string]interface{}{
-							"line":   loc.Line,
-							"column": loc.Column,
-						}
-					}
-					errorObj["locations"] = locations
-				}
+				errorObj := graphQLErrorObject(gqlErr)
 
 				// Since protobuf can't handle []map[string]interface{} directly,
 				// we'll serialize the errors as a JSON string and let the engine handle it
@@ -979,6 +1837,7 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 					"graphql_errors":   string(errorsJSON), // Send as JSON string
 					"data":             nil,
 					"is_graphql_error": true, // Flag to indicate this is a GraphQL error
+					"request_id":       GetRequestID(ctx),
 				}
 
 				resultStruct, err := structpb.NewStruct(errorResult)
@@ -1004,11 +1863,23 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 				}, nil
 			} else {
 				// Convert regular errors to GraphQL errors for GraphQL operations
+				extensions := map[string]interface{}{
+					"code": "INTERNAL_ERROR",
+				}
+				if codedErr := GetCodedError(err); codedErr != nil {
+					if code, known := graphQLErrorCodes[codedErr.Code]; known {
+						extensions["code"] = code
+					}
+					extensions["httpStatus"] = codedErr.Code
+				}
+				if IsRetryable(err) {
+					extensions["retryable"] = true
+					extensions["retryAfterMs"] = GetRetryBackoff(err).Milliseconds()
+				}
+
 				errorObj := map[string]interface{}{
-					"message": err.Error(),
-					"extensions": map[string]interface{}{
-						"code": "INTERNAL_ERROR",
-					},
+					"message":    err.Error(),
+					"extensions": extensions,
 				}
 
 				// Serialize as JSON string for protobuf compatibility
@@ -1024,6 +1895,7 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 					"graphql_errors":   string(errorsJSON),
 					"data":             nil,
 					"is_graphql_error": true,
+					"request_id":       GetRequestID(ctx),
 				}
 
 				resultStruct, err := structpb.NewStruct(errorResult)
@@ -1050,17 +1922,160 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 			}
 		} else {
 			// For REST API and functions, keep the original error handling
+			return impl.errorResponse(ctx, req, err, headers), nil
+		}
+	}
+
+	// Partial GraphQL results carry data and field errors together
+	if partial, ok := asPartialResult(result); ok && (req.FunctionType == "graphql_query" || req.FunctionType == "graphql_mutation") {
+		return impl.partialResultResponse(ctx, req, partial), nil
+	}
+
+	// File downloads are read into a binary result sent with a Content-Disposition header
+	if download, ok := asFileDownloadResult(result); ok {
+		binary, disposition, err := impl.plugin.readFileDownload(ctx, download)
+		if err != nil {
+			return impl.errorResponse(ctx, req, err, headers), nil
+		}
+		result = binary
+
+		downloadHeaders := make(map[string]interface{}, len(headers)+1)
+		for key, value := range headers {
+			downloadHeaders[key] = value
+		}
+		downloadHeaders["Content-Disposition"] = disposition
+		headers = downloadHeaders
+	}
+
+	// Binary results bypass JSON serialization and content negotiation entirely
+	if binary, ok := asBinaryResult(result); ok {
+		resultStruct := binaryResultStruct(binary, req.FunctionName, req.FunctionType)
+		if headers != nil {
+			if headersValue, err := structpb.NewValue(headers); err == nil {
+				resultStruct.Fields["headers"] = headersValue
+			}
+		}
+		if err := impl.plugin.checkResponseSize(proto.Size(resultStruct)); err != nil {
+			return impl.errorResponse(ctx, req, err, headers), nil
+		}
+
+		anyResult, err := anypb.New(resultStruct)
+		if err != nil {
 			return &protobuff.ExecuteResponse{
 				Success: false,
-				Message: fmt.Sprintf("Execution failed: %v", err),
+				Message: fmt.Sprintf("Failed to create any result: %v", err),
 			}, nil
 		}
+
+		return &protobuff.ExecuteResponse{
+			Success: true,
+			Message: "Execution completed successfully (binary)",
+			Result:  anyResult,
+		}, nil
+	}
+
+	// Encode REST results in the content type the caller asked for, if one is registered
+	if req.FunctionType == "rest_api" {
+		if contentType, encoder := impl.plugin.negotiateContentType(args); encoder != nil {
+			body, err := encoder(result)
+			if err != nil {
+				return &protobuff.ExecuteResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to encode response as %s: %v", contentType, err),
+				}, nil
+			}
+			if err := impl.plugin.checkResponseSize(len(body)); err != nil {
+				return impl.errorResponse(ctx, req, err, headers), nil
+			}
+
+			encodedResult := map[string]interface{}{
+				"data":          string(body),
+				"content_type":  contentType,
+				"function_name": req.FunctionName,
+				"function_type": req.FunctionType,
+			}
+			if headers != nil {
+				encodedResult["headers"] = headers
+			}
+
+			resultStruct, err := structpb.NewStruct(encodedResult)
+			if err != nil {
+				return &protobuff.ExecuteResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to create result struct: %v", err),
+				}, nil
+			}
+
+			anyResult, err := anypb.New(resultStruct)
+			if err != nil {
+				return &protobuff.ExecuteResponse{
+					Success: false,
+					Message: fmt.Sprintf("Failed to create any result: %v", err),
+				}, nil
+			}
+
+			return &protobuff.ExecuteResponse{
+				Success: true,
+				Message: fmt.Sprintf("Execution completed successfully (%s)", contentType),
+				Result:  anyResult,
+			}, nil
+		}
+	}
+
+	// Wrap REST results in the standard envelope when enabled
+	if req.FunctionType == "rest_api" && impl.plugin.restEnvelope.Load() {
+		result = wrapRESTResult(result)
+	}
+
+	// Make sure the result can be represented as JSON/structpb before choosing a serialization path
+	result, err = impl.plugin.sanitizeResult(req.FunctionName, result)
+	if err != nil {
+		return impl.errorResponse(ctx, req, err, headers), nil
+	}
+
+	// Top-level result fields besides the data: response headers, and parsing and timing
+	// details echoed back to the caller in debug mode
+	extras := make(map[string]interface{})
+	if headers != nil {
+		extras["headers"] = headers
+	}
+	if impl.plugin.debugMode.Load() {
+		extras["__debug"] = impl.buildDebugInfo(req, args, duration)
+	}
+
+	// Fast path: primitive results need neither the reflection scan nor a generic map conversion
+	if resultStruct, ok := primitiveResultStruct(result, req.FunctionName, req.FunctionType); ok {
+		for key, extra := range extras {
+			if extraValue, err := structpb.NewValue(extra); err == nil {
+				resultStruct.Fields[key] = extraValue
+			}
+		}
+		if err := impl.plugin.checkResponseSize(proto.Size(resultStruct)); err != nil {
+			return impl.errorResponse(ctx, req, err, headers), nil
+		}
+
+		anyResult, err := anypb.New(resultStruct)
+		if err != nil {
+			return &protobuff.ExecuteResponse{
+				Success: false,
+				Message: fmt.Sprintf("Failed to create any result: %v", err),
+			}, nil
+		}
+
+		return &protobuff.ExecuteResponse{
+			Success: true,
+			Message: "Execution completed successfully",
+			Result:  anyResult,
+		}, nil
 	}
 
 	// Convert result to protobuf Any
 	if isComplexArrayData(result) {
 		log.Printf("🎯 [SDK] Detected complex array data, using JSON bytes serialization")
-		anyResult, err := serializeComplexData(result, req.FunctionName, req.FunctionType)
+		anyResult, err := serializeComplexData(result, req.FunctionName, req.FunctionType, impl.plugin.maxResponseBytes.Load(), extras)
+		if IsCodedError(err) {
+			return impl.errorResponse(ctx, req, err, headers), nil
+		}
 		if err != nil {
 			return &protobuff.ExecuteResponse{
 				Success: false,
@@ -1081,6 +2096,9 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 		"function_name": req.FunctionName,
 		"function_type": req.FunctionType,
 	}
+	for key, extra := range extras {
+		resultMap[key] = extra
+	}
 
 	resultStruct, err := structpb.NewStruct(resultMap)
 	if err != nil {
@@ -1089,6 +2107,9 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 			Message: fmt.Sprintf("Failed to create result struct: %v", err),
 		}, nil
 	}
+	if err := impl.plugin.checkResponseSize(proto.Size(resultStruct)); err != nil {
+		return impl.errorResponse(ctx, req, err, headers), nil
+	}
 
 	anyResult, err := anypb.New(resultStruct)
 	if err != nil {
@@ -1105,6 +2126,53 @@ func (impl *pluginImpl) Execute(ctx context.Context, req *protobuff.ExecuteReque
 	}, nil
 }
 
+// errorResponse builds a failed ExecuteResponse that keeps the original message and carries the
+// structured error (code, message, details) in the result so the host can map HTTP statuses.
+// headers, when non-nil, are the REST response headers to send along with the error.
+func (impl *pluginImpl) errorResponse(ctx context.Context, req *protobuff.ExecuteRequest, err error, headers map[string]interface{}) *protobuff.ExecuteResponse {
+	response := &protobuff.ExecuteResponse{
+		Success: false,
+		Message: fmt.Sprintf("Execution failed: %v", err),
+	}
+
+	errorResult := map[string]interface{}{
+		"error":         buildErrorObject(err),
+		"function_name": req.FunctionName,
+		"function_type": req.FunctionType,
+	}
+	if requestID := GetRequestID(ctx); requestID != "" {
+		errorResult["request_id"] = requestID
+	}
+	if req.FunctionType == "rest_api" && impl.plugin.restEnvelope.Load() {
+		errorResult["data"] = wrapRESTError(err)
+	}
+	if headers != nil {
+		errorResult["headers"] = headers
+	}
+
+	if resultStruct, structErr := structpb.NewStruct(errorResult); structErr == nil {
+		if anyResult, anyErr := anypb.New(resultStruct); anyErr == nil {
+			response.Result = anyResult
+		}
+	}
+
+	return response
+}
+
+// checkResponseSize returns a 413 CodedError when a serialized response exceeds the configured limit
+func (p *Plugin) checkResponseSize(size int) error {
+	if limit := p.maxResponseBytes.Load(); limit > 0 && int64(size) > limit {
+		return PayloadTooLargeError("Response too large", fmt.Sprintf("response is %d bytes, limit is %d bytes", size, limit))
+	}
+	return nil
+}
+
+// SetMaxResponseBytes sets the largest serialized response the plugin will send.
+// The default is DefaultMaxResponseBytes (gRPC's 4MB message limit); 0 disables the guard.
+func (p *Plugin) SetMaxResponseBytes(n int64) {
+	p.maxResponseBytes.Store(n)
+}
+
 func (impl *pluginImpl) Debug(ctx context.Context, req *protobuff.DebugRequest) (*protobuff.DebugResponse, error) {
 
 	result := map[string]interface{}{
@@ -1114,6 +2182,22 @@ func (impl *pluginImpl) Debug(ctx context.Context, req *protobuff.DebugRequest)
 		"message": "Debug method called successfully",
 	}
 
+	// Dispatch to the probe registered for the requested stage, if any
+	impl.plugin.mu.RLock()
+	probe, exists := impl.plugin.debugProbes[req.Stage]
+	impl.plugin.mu.RUnlock()
+
+	if exists {
+		data, err := probe(ctx)
+		if err != nil {
+			result["message"] = fmt.Sprintf("Debug probe '%s' failed", req.Stage)
+			result["error"] = err.Error()
+		} else {
+			result["message"] = fmt.Sprintf("Debug probe '%s' completed", req.Stage)
+			result["data"] = data
+		}
+	}
+
 	resultStruct, err := structpb.NewStruct(result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create result struct: %v", err)
@@ -1131,29 +2215,39 @@ func (impl *pluginImpl) Debug(ctx context.Context, req *protobuff.DebugRequest)
 
 // performHealthCheck performs a comprehensive health check of the plugin
 func (p *Plugin) performHealthCheck(ctx context.Context) (interface{}, error) {
-	startTime := time.Now()
+	now := time.Now()
 
-	// Basic plugin health information
+	// Basic plugin health information; uptime is in milliseconds since Init
 	healthInfo := map[string]interface{}{
-		"status":    "healthy",
-		"plugin":    p.name,
-		"version":   p.version,
-		"timestamp": time.Now().Unix(),
-		"uptime":    time.Since(startTime).Milliseconds(),
+		"status":         "healthy",
+		"plugin":         p.name,
+		"version":        p.version,
+		"timestamp":      now.Unix(),
+		"started_at":     p.startedAt.Unix(),
+		"uptime":         now.Sub(p.startedAt).Milliseconds(),
+		"process_uptime": now.Sub(processStartTime).Milliseconds(),
 	}
 
 	// Runtime information
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
-	healthInfo["runtime"] = map[string]interface{}{
+	runtimeInfo := map[string]interface{}{
 		"goroutines":       runtime.NumGoroutine(),
 		"memory_allocated": memStats.Alloc,
 		"memory_total":     memStats.TotalAlloc,
 		"memory_sys":       memStats.Sys,
 		"gc_cycles":        memStats.NumGC,
 		"go_version":       runtime.Version(),
+		"open_fds":         openFDCount(), // -1 when the OS doesn't expose it
 	}
+	healthInfo["runtime"] = runtimeInfo
+
+	// Snapshot the registries so custom checks run without holding the lock
+	p.mu.RLock()
+	healthChecks := append([]HealthCheckFunc(nil), p.healthChecks...)
+	diskPath := p.healthDiskPath
+	thresholds := p.healthThresholds
 
 	// Plugin registration statistics
 	healthInfo["statistics"] = map[string]interface{}{
@@ -1173,6 +2267,13 @@ func (p *Plugin) performHealthCheck(ctx context.Context) (interface{}, error) {
 		"custom_functions":  len(p.functions) > 0,
 		"health_checks":     len(p.healthChecks) > 0,
 	}
+	p.mu.RUnlock()
+
+	if diskPath != "" {
+		if disk := diskUsageInfo(diskPath); disk != nil {
+			runtimeInfo["disk"] = disk
+		}
+	}
 
 	// Environment information
 	healthInfo["environment"] = map[string]interface{}{
@@ -1186,7 +2287,16 @@ func (p *Plugin) performHealthCheck(ctx context.Context) (interface{}, error) {
 	customHealthResults := make(map[string]interface{})
 	overallStatus := "healthy"
 
-	for i, healthCheck := range p.healthChecks {
+	// Resource thresholds degrade the status without needing a custom check
+	if details, reasons := thresholds.check(runtime.NumGoroutine(), memStats.HeapAlloc); len(details) > 0 {
+		healthInfo["thresholds"] = details
+		if len(reasons) > 0 {
+			healthInfo["degraded_reasons"] = reasons
+			overallStatus = "degraded"
+		}
+	}
+
+	for i, healthCheck := range healthChecks {
 		checkName := fmt.Sprintf("custom_check_%d", i)
 		checkResult, err := healthCheck(ctx)
 		if err != nil {
@@ -1204,16 +2314,104 @@ func (p *Plugin) performHealthCheck(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	if len(p.healthChecks) > 0 {
+	if len(healthChecks) > 0 {
 		healthInfo["custom_health_checks"] = customHealthResults
 	}
 
+	// A draining plugin is still alive but should no longer receive traffic
+	if p.IsDraining() {
+		overallStatus = "draining"
+	}
+
 	// Update overall status based on custom checks
 	healthInfo["status"] = overallStatus
 
 	return healthInfo, nil
 }
 
+// ping echoes the arguments as the plugin received them after the structpb round-trip, with the
+// Go type of each so lossy conversions (e.g. integers arriving as float64) are visible. Host
+// context values are listed by name only and redacted keys are masked.
+func (p *Plugin) ping(args map[string]interface{}) map[string]interface{} {
+	echo := make(map[string]interface{}, len(args))
+	argTypes := make(map[string]interface{}, len(args))
+	contextKeys := make([]string, 0)
+	for key, value := range args {
+		if strings.HasPrefix(key, "context_") {
+			contextKeys = append(contextKeys, strings.TrimPrefix(key, "context_"))
+			continue
+		}
+		echo[key] = value
+		argTypes[key] = fmt.Sprintf("%T", value)
+	}
+	sort.Strings(contextKeys)
+
+	contextList := make([]interface{}, len(contextKeys))
+	for i, key := range contextKeys {
+		contextList[i] = key
+	}
+
+	return map[string]interface{}{
+		"pong":         true,
+		"plugin":       p.name,
+		"version":      p.version,
+		"sdk_version":  Version,
+		"timestamp":    time.Now().UTC().Format(time.RFC3339Nano),
+		"args":         redactArgs(echo, p.redactionSet()),
+		"arg_types":    argTypes,
+		"context_keys": contextList,
+	}
+}
+
+// introspect describes everything the plugin exposes, using the same serialization the engine sees
+func (p *Plugin) introspect() map[string]interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	model := p.schemaModel()
+
+	queries := make(map[string]interface{}, len(model.Queries))
+	for _, schemaField := range model.Queries {
+		queries[schemaField.Name] = p.impl.serializeGraphQLField(schemaField.Field)
+	}
+
+	mutations := make(map[string]interface{}, len(model.Mutations))
+	for _, schemaField := range model.Mutations {
+		mutations[schemaField.Name] = p.impl.serializeGraphQLField(schemaField.Field)
+	}
+
+	restEndpoints := make([]interface{}, len(p.restAPIs))
+	for i, endpoint := range p.restAPIs {
+		restEndpoints[i] = map[string]interface{}{
+			"method":      endpoint.Method,
+			"path":        endpoint.Path,
+			"description": endpoint.Description,
+			"handler":     endpoint.Handler,
+		}
+	}
+
+	functions := make([]interface{}, len(model.Functions))
+	for i, name := range model.Functions {
+		functions[i] = name
+	}
+
+	objectTypes := make([]interface{}, len(model.ObjectTypes))
+	for i, objectType := range model.ObjectTypes {
+		objectTypes[i] = objectType.TypeName
+	}
+
+	return map[string]interface{}{
+		"plugin":         p.name,
+		"version":        p.version,
+		"queries":        queries,
+		"mutations":      mutations,
+		"rest_endpoints": restEndpoints,
+		"functions":      functions,
+		"object_types":   objectTypes,
+		"capabilities":   p.Capabilities().toMap(),
+	}
+}
+
 // getHostname safely gets the hostname
 func getHostname() string {
 	hostname, err := os.Hostname()
@@ -1223,8 +2421,82 @@ func getHostname() string {
 	return hostname
 }
 
+// OnInit registers a hook that runs when the host initializes the plugin, after environment
+// variables are applied. Use it to open connections or warm caches; returning an error fails init.
+func (p *Plugin) OnInit(hook InitHookFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.initHooks = append(p.initHooks, hook)
+}
+
+// OnShutdown registers a hook that runs after the plugin server stops, in registration order.
+// Use it to close connections and flush buffers; errors are logged and don't stop later hooks.
+func (p *Plugin) OnShutdown(hook ShutdownHookFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.shutdownHooks = append(p.shutdownHooks, hook)
+}
+
+// runShutdownHooks runs the OnShutdown hooks, giving them DefaultShutdownTimeout in total
+func (p *Plugin) runShutdownHooks() {
+	p.BeginDraining()
+
+	p.mu.RLock()
+	shutdownHooks := append([]ShutdownHookFunc(nil), p.shutdownHooks...)
+	p.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+	defer cancel()
+
+	for i, hook := range shutdownHooks {
+		if err := hook(ctx); err != nil {
+			log.Printf("Plugin SDK: Shutdown hook %d for plugin '%s' failed: %v", i, p.name, err)
+		}
+	}
+}
+
+// processStartTime approximates the process start: package variables are initialized before main runs
+var processStartTime = time.Now()
+
+// Uptime returns how long the plugin has been running since Init
+func (p *Plugin) Uptime() time.Duration {
+	return time.Since(p.startedAt)
+}
+
+// BeginDraining marks the plugin as shutting down: the health check (and the readiness probe
+// of EnableHealthEndpoint) reports status "draining" so load balancers stop routing to it,
+// while the liveness probe stays healthy. ServeContext calls it before stopping the server;
+// call it earlier, e.g. on SIGTERM, to give in-flight traffic time to move elsewhere.
+func (p *Plugin) BeginDraining() {
+	if p.draining.CompareAndSwap(false, true) {
+		log.Printf("Plugin SDK: Plugin '%s' is draining", p.name)
+	}
+}
+
+// IsDraining reports whether BeginDraining has been called
+func (p *Plugin) IsDraining() bool {
+	return p.draining.Load()
+}
+
+// DefaultShutdownTimeout bounds how long the OnShutdown hooks may run
+const DefaultShutdownTimeout = 10 * time.Second
+
+// RegisterDebugProbe registers a probe that the Debug RPC runs for the given stage
+// (e.g. "connections", "config", "cache"). The probe output must be protobuf-compatible.
+func (p *Plugin) RegisterDebugProbe(stage string, probe DebugProbeFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.debugProbes[stage] = probe
+}
+
 // RegisterHealthCheck registers a custom health check function
 func (p *Plugin) RegisterHealthCheck(healthCheck HealthCheckFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.healthChecks = append(p.healthChecks, healthCheck)
 }
 
@@ -1234,3 +2506,30 @@ func (p *Plugin) RegisterHealthChecks(healthChecks []HealthCheckFunc) {
 		p.RegisterHealthCheck(healthCheck)
 	}
 }
+
+// EnableHealthEndpoint registers a GET REST endpoint (default "/health") returning the same
+// payload as the built-in health_check function, so load balancers can probe the plugin over HTTP.
+// "?kind=liveness" skips the custom health checks and only reports that the process is up,
+// even while draining; any other request is a readiness probe.
+func (p *Plugin) EnableHealthEndpoint(path string) {
+	if path == "" {
+		path = "/health"
+	}
+
+	p.RegisterRESTAPI(RESTEndpoint{
+		Method:      "GET",
+		Path:        path,
+		Description: "Plugin health check",
+	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		if GetQueryParam(args, "kind") == "liveness" {
+			return map[string]interface{}{
+				"status":    "healthy",
+				"kind":      "liveness",
+				"plugin":    p.name,
+				"version":   p.version,
+				"timestamp": time.Now().Unix(),
+			}, nil
+		}
+		return p.performHealthCheck(ctx)
+	})
+}
@@ -0,0 +1,324 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// registerSchemaFixture registers a small schema with object types, queries and mutations
+func registerSchemaFixture() *Plugin {
+	p := Init("test-plugin", "1.0.0", "")
+
+	NewObjectType("Address", "A postal address").
+		AddStringField("street", "Street", false).
+		AddStringField("city", "City", false).
+		AddStringField("zip", "Postal code", true).
+		Build()
+	user := NewObjectType("User", "A user").
+		AddIDField("id", "User ID", false).
+		AddStringField("name", "Name", false).
+		AddObjectField("address", "Address", "Address", true).
+		AddIntField("age", "Age", true).
+		AddBooleanField("active", "Whether the user is active", false).
+		Build()
+
+	resolver := func(ctx context.Context, args map[string]interface{}) (interface{}, error) { return nil, nil }
+	p.RegisterQuery("getUser", ComplexObjectFieldWithArgs("Get a user", user, map[string]interface{}{
+		"id": NonNullArg("ID", "User ID"),
+	}), resolver)
+	p.RegisterQuery("listUsers", ListOfObjectsField("List users", user), resolver)
+	p.RegisterMutation("deleteUser", FieldWithArgs("Boolean", "Delete a user", map[string]interface{}{
+		"id": NonNullArg("ID", "User ID"),
+	}), resolver)
+	return p
+}
+
+func TestSchemaSerializationIsStable(t *testing.T) {
+	marshal := func() []byte {
+		p := registerSchemaFixture()
+		schema, err := p.impl.cachedSchema()
+		if err != nil {
+			t.Fatalf("cachedSchema() error = %v", err)
+		}
+		opts := proto.MarshalOptions{Deterministic: true}
+		queries, err := opts.Marshal(schema.Queries)
+		if err != nil {
+			t.Fatalf("marshal queries: %v", err)
+		}
+		mutations, err := opts.Marshal(schema.Mutations)
+		if err != nil {
+			t.Fatalf("marshal mutations: %v", err)
+		}
+		return append(queries, mutations...)
+	}
+
+	first := marshal()
+	for i := 0; i < 20; i++ {
+		if next := marshal(); !bytes.Equal(first, next) {
+			t.Fatalf("serialization %d differs from the first one", i+1)
+		}
+	}
+}
+
+func TestObjectTypeFieldPositionsFollowDeclarationOrder(t *testing.T) {
+	p := registerSchemaFixture()
+	user, _ := p.GetObjectType("User")
+	serialized := p.impl.serializeObjectTypeDefinition(user)
+	fields := serialized["fields"].(map[string]interface{})
+
+	for want, name := range []string{"id", "name", "address", "age", "active"} {
+		field, ok := fields[name].(map[string]interface{})
+		if !ok {
+			t.Fatalf("field %q missing from serialized User", name)
+		}
+		if got := field["position"]; got != want {
+			t.Errorf("field %q position = %v, want %d", name, got, want)
+		}
+	}
+}
+
+// TestConcurrentRegistrationAndExecute is meant for the race detector: go test -race
+func TestConcurrentRegistrationAndExecute(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	echo := func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return args["value"], nil
+	}
+	p.RegisterQuery("echo", FieldWithArgs("String", "Echo a value", map[string]interface{}{
+		"value": StringArg("Value to echo"),
+	}), echo)
+
+	args, err := structpb.NewStruct(map[string]interface{}{"value": "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				name := fmt.Sprintf("query_%d_%d", w, i)
+				p.RegisterQuery(name, Field("String", "Generated query"), echo)
+				p.RegisterMutation("m_"+name, Field("String", "Generated mutation"), echo)
+				p.RegisterFunction("f_"+name, FunctionHandlerFunc(echo))
+				NewObjectType(fmt.Sprintf("Type_%d_%d", w, i), "Generated type").AddStringField("id", "ID", false).Build()
+			}
+		}(w)
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{
+					FunctionType: "graphql_query",
+					FunctionName: "echo",
+					Args:         args,
+				})
+				if err != nil || !resp.Success {
+					t.Errorf("Execute() = %v, %v", resp, err)
+					return
+				}
+				if _, err := p.impl.SchemaRegister(context.Background(), &protobuff.SchemaRegisterRequest{}); err != nil {
+					t.Errorf("schema: %v", err)
+					return
+				}
+				p.GetQueryField("echo")
+				p.ResolverNames()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(p.ResolverNames()); got != 1+2*workers*50 {
+		t.Errorf("ResolverNames() = %d entries, want %d", got, 1+2*workers*50)
+	}
+}
+
+// registerLargeSchema registers n object types, each returned by its own query
+func registerLargeSchema(n int) *Plugin {
+	p := Init("bench-plugin", "1.0.0", "")
+	resolver := func(ctx context.Context, args map[string]interface{}) (interface{}, error) { return nil, nil }
+	for i := 0; i < n; i++ {
+		def := NewObjectType(fmt.Sprintf("Type%d", i), "Generated type").
+			AddIDField("id", "ID", false).
+			AddStringField("name", "Name", true).
+			AddIntField("count", "Count", true).
+			AddStringListField("tags", "Tags", true, true).
+			Build()
+		p.RegisterQuery(fmt.Sprintf("get%d", i), ComplexObjectFieldWithArgs("Get a type", def, map[string]interface{}{
+			"id": NonNullArg("ID", "ID"),
+		}), resolver)
+	}
+	return p
+}
+
+func BenchmarkSchemaRegister100ObjectTypes(b *testing.B) {
+	p := registerLargeSchema(100)
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := p.impl.cachedSchema(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("rebuilt", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.mu.RLock()
+			_, err := p.impl.buildSchema()
+			p.mu.RUnlock()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkShortStringResult(b *testing.B) {
+	b.Run("fast_path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, ok := primitiveResultStruct("ok", "status", "graphql_query"); !ok {
+				b.Fatal("short string did not take the fast path")
+			}
+		}
+	})
+
+	b.Run("generic_path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if isComplexArrayData("ok") {
+				b.Fatal("short string reported as complex data")
+			}
+			if _, err := structpb.NewStruct(map[string]interface{}{
+				"data":          "ok",
+				"function_name": "status",
+				"function_type": "graphql_query",
+			}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("execute", func(b *testing.B) {
+		p := Init("bench-plugin", "1.0.0", "")
+		p.RegisterQuery("status", Field("String", "Status"), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+		req := &protobuff.ExecuteRequest{FunctionType: "graphql_query", FunctionName: "status"}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			resp, err := p.impl.Execute(context.Background(), req)
+			if err != nil || !resp.Success {
+				b.Fatalf("Execute() = %v, %v", resp, err)
+			}
+		}
+	})
+}
+
+func BenchmarkSerializeComplexDataSliceOfMaps(b *testing.B) {
+	rows := make([]map[string]interface{}, 1000)
+	for i := range rows {
+		rows[i] = map[string]interface{}{
+			"id":     fmt.Sprintf("row-%d", i),
+			"name":   fmt.Sprintf("Row %d", i),
+			"count":  i,
+			"active": i%2 == 0,
+			"tags":   []interface{}{"a", "b", "c"},
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := serializeComplexData(rows, "list", "graphql_query", 0, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWrappedCodedErrorKeepsStatusCode(t *testing.T) {
+	notFound := NotFoundError("user not found")
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"direct", notFound, 404},
+		{"wrapped once", fmt.Errorf("load user: %w", notFound), 404},
+		{"wrapped twice", fmt.Errorf("resolver: %w", fmt.Errorf("load user: %w", notFound)), 404},
+		{"wrapped with code", WrapWithCode(404, "missing", errors.New("no rows")), 404},
+		{"plain error", errors.New("boom"), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetErrorCode(tt.err); got != tt.code {
+				t.Errorf("GetErrorCode() = %d, want %d", got, tt.code)
+			}
+			if codedErr := GetCodedError(tt.err); (codedErr != nil) != (tt.code != 500) {
+				t.Errorf("GetCodedError() = %v", codedErr)
+			}
+		})
+	}
+}
+
+func TestDeadlineErrorIsGatewayTimeout(t *testing.T) {
+	err := deadlineError(fmt.Errorf("query users: %w", context.DeadlineExceeded))
+	if GetErrorCode(err) != 504 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("deadlineError() = %v (code %d), want a 504 that still matches context.DeadlineExceeded", err, GetErrorCode(err))
+	}
+}
+
+func TestUptimeIncreases(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	const pause = 20 * time.Millisecond
+
+	healthMillis := func(key string) func() time.Duration {
+		return func() time.Duration {
+			health, err := p.performHealthCheck(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return time.Duration(health.(map[string]interface{})[key].(int64)) * time.Millisecond
+		}
+	}
+
+	tests := []struct {
+		name   string
+		uptime func() time.Duration
+	}{
+		{"Uptime", p.Uptime},
+		{"health uptime", healthMillis("uptime")},
+		{"health process_uptime", healthMillis("process_uptime")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.uptime()
+			time.Sleep(pause)
+			after := tt.uptime()
+			// Health reports whole milliseconds, so allow for truncation of both readings
+			if after-before < pause-time.Millisecond {
+				t.Errorf("uptime went from %v to %v after sleeping %v", before, after, pause)
+			}
+		})
+	}
+}
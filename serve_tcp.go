@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Handshake values shared by Serve and ServeTCP
+const (
+	MagicCookieKey   = "APITO_PLUGIN"
+	MagicCookieValue = "apito_plugin_magic_cookie_v1"
+)
+
+// ServeTCP serves the plugin's gRPC service on a TCP address (e.g. ":50051") for hosts that
+// connect over the network instead of launching the plugin as a subprocess. It blocks until
+// the server stops.
+//
+// The magic cookie handshake becomes per-call metadata: every request must carry the
+// "apito_plugin" metadata key set to MagicCookieValue. The cookie only guards against
+// connecting to the wrong service, not against attackers, and the connection is plaintext
+// unless TLS credentials are passed in opts (grpc.Creds). Bind to a private interface or use TLS.
+func (p *Plugin) ServeTCP(addr string, opts ...grpc.ServerOption) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+
+	serverOpts := append([]grpc.ServerOption{grpc.UnaryInterceptor(magicCookieInterceptor)}, p.GRPCServerOptions()...)
+	serverOpts = append(serverOpts, opts...)
+	server := grpc.NewServer(serverOpts...)
+	protobuff.RegisterPluginServiceServer(server, p.impl)
+
+	log.Printf("Plugin SDK: Serving plugin '%s' over TCP on %s", p.name, listener.Addr())
+	return server.Serve(listener)
+}
+
+// magicCookieInterceptor rejects calls that don't carry the plugin magic cookie
+func magicCookieInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(strings.ToLower(MagicCookieKey))
+	if len(values) == 0 || values[0] != MagicCookieValue {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid plugin magic cookie")
+	}
+	return handler(ctx, req)
+}
+
+// SetMaxGRPCMessageSize raises (or lowers) gRPC's 4MB limit on received and sent messages for
+// Serve, ServeTCP and ServeWithTLS. Large schemas and REST payloads otherwise fail with
+// "message larger than max". The response-size guard (SetMaxResponseBytes) is moved along with
+// it while it is still at its DefaultMaxResponseBytes default; an explicit limit is kept.
+func (p *Plugin) SetMaxGRPCMessageSize(bytes int) {
+	p.maxGRPCMessageSize.Store(int64(bytes))
+	if bytes > 0 {
+		p.maxResponseBytes.CompareAndSwap(DefaultMaxResponseBytes, int64(bytes))
+	}
+}
+
+// MaxGRPCMessageSize returns the message size limit set with SetMaxGRPCMessageSize, or 0 for gRPC's default
+func (p *Plugin) MaxGRPCMessageSize() int {
+	return int(p.maxGRPCMessageSize.Load())
+}
+
+// GRPCServerOptions returns the server options derived from the plugin's settings,
+// for hosting the GRPCServer service on a custom gRPC server
+func (p *Plugin) GRPCServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if size := p.MaxGRPCMessageSize(); size > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(size), grpc.MaxSendMsgSize(size))
+	}
+	return append(opts, p.keepaliveServerOptions()...)
+}
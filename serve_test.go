@@ -0,0 +1,140 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serveForTest runs ServeContext with the plugin handshake environment and returns the address
+// from the handshake line along with a channel that receives ServeContext's result. The process
+// stdout and stderr, which the plugin server takes over, are restored once it returns.
+func serveForTest(t *testing.T, p *Plugin, ctx context.Context) (<-chan string, <-chan error) {
+	t.Helper()
+	t.Setenv(MagicCookieKey, MagicCookieValue)
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout = writer
+
+	addr := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(reader).ReadString('\n')
+		// CORE-PROTOCOL|APP-PROTOCOL|NETWORK|ADDR|PROTOCOL|CERT
+		if parts := strings.Split(strings.TrimSpace(line), "|"); len(parts) >= 4 {
+			addr <- parts[2] + ":" + parts[3]
+		}
+		close(addr)
+	}()
+
+	result := make(chan error, 1)
+	go func() {
+		err := p.ServeContext(ctx)
+		os.Stdout, os.Stderr = stdout, stderr
+		writer.Close()
+		result <- err
+	}()
+	return addr, result
+}
+
+func TestServeContextCancelledBeforeServerStarts(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	var hookRan bool
+	p.OnShutdown(func(ctx context.Context) error {
+		hookRan = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, result := serveForTest(t, p, ctx)
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("ServeContext() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeContext did not return after cancellation")
+	}
+	if !hookRan {
+		t.Error("shutdown hooks did not run")
+	}
+}
+
+func TestServeContextFinishesInFlightCalls(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	started, release := make(chan struct{}), make(chan struct{})
+	p.RegisterQuery("slow", Field("String", "Slow query"), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		close(started)
+		<-release
+		return "done", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	addrs, result := serveForTest(t, p, ctx)
+
+	addr, ok := <-addrs
+	if !ok {
+		t.Fatal("plugin server did not print its address")
+	}
+	network, target, _ := strings.Cut(addr, ":")
+	conn, err := grpc.NewClient("passthrough:///"+target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, target string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, target)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	type callResult struct {
+		resp *protobuff.ExecuteResponse
+		err  error
+	}
+	call := make(chan callResult, 1)
+	go func() {
+		resp, err := protobuff.NewPluginServiceClient(conn).Execute(context.Background(), &protobuff.ExecuteRequest{
+			FunctionType: "graphql_query",
+			FunctionName: "slow",
+		})
+		call <- callResult{resp, err}
+	}()
+
+	<-started
+	cancel()
+	select {
+	case err := <-result:
+		t.Fatalf("ServeContext() returned %v while a call was in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	got := <-call
+	if got.err != nil || !got.resp.Success {
+		t.Fatalf("in-flight Execute() = %v, %v", got.resp, got.err)
+	}
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("ServeContext() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeContext did not return after the in-flight call finished")
+	}
+}
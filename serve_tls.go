@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ServeWithTLS serves the plugin over TCP like ServeTCP, with transport security from tlsConfig.
+// When tlsConfig has ClientCAs, client certificates are required and verified (mutual TLS), so
+// only a host holding a certificate signed by those CAs can connect.
+// The config is validated up front and an error is returned if no server certificate is set.
+func (p *Plugin) ServeWithTLS(addr string, tlsConfig *tls.Config, opts ...grpc.ServerOption) error {
+	if err := validateTLSConfig(tlsConfig); err != nil {
+		return err
+	}
+
+	config := tlsConfig.Clone()
+	if config.ClientCAs != nil && config.ClientAuth < tls.RequireAndVerifyClientCert {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	return p.ServeTCP(addr, append([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, opts...)...)
+}
+
+// validateTLSConfig checks that a server TLS config can actually serve connections
+func validateTLSConfig(tlsConfig *tls.Config) error {
+	if tlsConfig == nil {
+		return errors.New("TLS config is required")
+	}
+	if len(tlsConfig.Certificates) == 0 && tlsConfig.GetCertificate == nil && tlsConfig.GetConfigForClient == nil {
+		return errors.New("TLS config has no server certificate")
+	}
+	if tlsConfig.ClientAuth >= tls.VerifyClientCertIfGiven && tlsConfig.ClientCAs == nil {
+		return errors.New("TLS config verifies client certificates but has no ClientCAs")
+	}
+	return nil
+}
+
+// LoadMutualTLSConfig builds a server TLS config from PEM files. When clientCAFile is not
+// empty, clients must present a certificate signed by one of its CAs (mutual TLS).
+func LoadMutualTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile == "" {
+		return config, nil
+	}
+
+	caPEM, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %v", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+	}
+	config.ClientCAs = clientCAs
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
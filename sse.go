@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SSEContentType is the content type of server-sent event responses
+const SSEContentType = "text/event-stream"
+
+// SSESendFunc emits one server-sent event. data is sent as-is when it is a string and as JSON
+// otherwise; an empty event name produces an unnamed ("message") event. It returns the
+// context's error once the request is cancelled or past its deadline, so handlers should stop.
+type SSESendFunc func(event string, data interface{}) error
+
+// SSEHandlerFunc produces server-sent events for a REST endpoint
+type SSEHandlerFunc func(ctx context.Context, args map[string]interface{}, send SSESendFunc) error
+
+// BufferedSSEHandler adapts an SSEHandlerFunc to a REST handler whose result the host relays as
+// text/event-stream. It is not live SSE: Execute is a unary RPC, so every event is buffered
+// until the handler returns and the client then receives them all at once in a single body.
+// Clients get no incremental progress updates, so it is not suitable for job progress, and the
+// buffered events count against the response size limit. Use it only for clients that expect
+// the SSE format; live streaming needs a streaming RPC the plugin protocol doesn't have yet.
+// Capabilities reports FeatureBufferedSSE accordingly.
+//
+// If the handler fails after sending events, the buffer ends with an "error" event carrying
+// {code, message}; if it fails before sending any, the error is returned as usual.
+func BufferedSSEHandler(handler SSEHandlerFunc) RESTHandlerFunc {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		var stream bytes.Buffer
+		eventID := 0
+
+		send := func(event string, data interface{}) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			eventID++
+			return writeSSEEvent(&stream, eventID, event, data)
+		}
+
+		if err := handler(ctx, args, send); err != nil {
+			if eventID == 0 {
+				return nil, err
+			}
+			eventID++
+			writeSSEEvent(&stream, eventID, "error", map[string]interface{}{
+				"code":    GetErrorCode(err),
+				"message": GetErrorMessage(err),
+			})
+		}
+
+		return BinaryResult{ContentType: SSEContentType, Data: stream.Bytes()}, nil
+	}
+}
+
+// RegisterBufferedSSE registers a REST endpoint served by an SSEHandlerFunc. The endpoint schema
+// declares the text/event-stream response content type and "responseDelivery": "buffered", so
+// hosts know the events arrive together when the handler returns; see BufferedSSEHandler.
+func (p *Plugin) RegisterBufferedSSE(endpoint RESTEndpoint, handler SSEHandlerFunc) {
+	schema := make(map[string]interface{}, len(endpoint.Schema)+2)
+	for key, value := range endpoint.Schema {
+		schema[key] = value
+	}
+	schema["responseContentType"] = SSEContentType
+	schema["responseDelivery"] = "buffered"
+	endpoint.Schema = schema
+
+	p.RegisterRESTAPI(endpoint, BufferedSSEHandler(handler))
+}
+
+// writeSSEEvent appends one event in the text/event-stream wire format
+func writeSSEEvent(stream *bytes.Buffer, id int, event string, data interface{}) error {
+	payload, ok := data.(string)
+	if !ok {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode SSE event data: %v", err)
+		}
+		payload = string(raw)
+	}
+
+	fmt.Fprintf(stream, "id: %d\n", id)
+	if event != "" {
+		fmt.Fprintf(stream, "event: %s\n", event)
+	}
+	// Each line of a multi-line payload needs its own data field
+	for _, line := range strings.Split(payload, "\n") {
+		fmt.Fprintf(stream, "data: %s\n", line)
+	}
+	stream.WriteString("\n")
+	return nil
+}
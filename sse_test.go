@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegisterBufferedSSEMarksEndpointBuffered(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterBufferedSSE(RESTEndpoint{Method: "GET", Path: "/events", Handler: "events"},
+		func(ctx context.Context, args map[string]interface{}, send SSESendFunc) error {
+			return send("tick", "1")
+		})
+
+	schema := p.restAPIs[0].Schema
+	if schema["responseContentType"] != SSEContentType || schema["responseDelivery"] != "buffered" {
+		t.Errorf("endpoint schema = %v, want the SSE content type with buffered delivery", schema)
+	}
+	if !p.Capabilities().HasFeature(FeatureBufferedSSE) {
+		t.Errorf("Capabilities() does not report %q", FeatureBufferedSSE)
+	}
+}
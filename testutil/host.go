@@ -0,0 +1,113 @@
+// Package testutil provides an in-memory host for exercising a plugin end-to-end over gRPC,
+// covering serialization, dispatch and response decoding without the Apito engine.
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	sdk "github.com/apito-io/go-apito-plugin-sdk"
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const bufferSize = 1024 * 1024
+
+// Host is an in-memory gRPC client connected to a plugin, acting as the Apito engine would
+type Host struct {
+	Client protobuff.PluginServiceClient
+
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+// NewHost serves the plugin on an in-memory listener and connects a client to it.
+// Call Close when done.
+func NewHost(p *sdk.Plugin) (*Host, error) {
+	listener := bufconn.Listen(bufferSize)
+
+	server := grpc.NewServer(p.GRPCServerOptions()...)
+	protobuff.RegisterPluginServiceServer(server, p.GRPCServer())
+	go server.Serve(listener)
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	// Match the plugin's message size limit so large schemas and results can be received
+	if size := p.MaxGRPCMessageSize(); size > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(size), grpc.MaxCallSendMsgSize(size)))
+	}
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	if err != nil {
+		server.Stop()
+		return nil, fmt.Errorf("failed to connect to plugin: %v", err)
+	}
+
+	return &Host{
+		Client: protobuff.NewPluginServiceClient(conn),
+		server: server,
+		conn:   conn,
+	}, nil
+}
+
+// Close disconnects the client and stops the in-memory server
+func (h *Host) Close() {
+	h.conn.Close()
+	h.server.Stop()
+}
+
+// SchemaRegister fetches the plugin's GraphQL schema as the host would at load time
+func (h *Host) SchemaRegister(ctx context.Context) (*protobuff.ThirdPartyGraphQLSchemas, error) {
+	resp, err := h.Client.SchemaRegister(ctx, &protobuff.SchemaRegisterRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Schema, nil
+}
+
+// RESTApiRegister fetches the plugin's REST endpoints as the host would at load time
+func (h *Host) RESTApiRegister(ctx context.Context) ([]*protobuff.ThirdPartyRESTApi, error) {
+	resp, err := h.Client.RESTApiRegister(ctx, &protobuff.RESTApiRegisterRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Apis, nil
+}
+
+// Execute calls a function on the plugin. functionType is one of "graphql_query",
+// "graphql_mutation", "rest_api" or "function"; requestContext is sent as the host context
+// (user_id, tenant_id, ...) and may be nil.
+func (h *Host) Execute(ctx context.Context, functionType, functionName string, args, requestContext map[string]interface{}) (*protobuff.ExecuteResponse, error) {
+	req, err := NewExecuteRequest(functionType, functionName, args, requestContext)
+	if err != nil {
+		return nil, err
+	}
+	return h.Client.Execute(ctx, req)
+}
+
+// ExecuteAndDecode calls a function on the plugin and returns the decoded result data.
+// A failed execution is returned as an error carrying the response message.
+func (h *Host) ExecuteAndDecode(ctx context.Context, functionType, functionName string, args, requestContext map[string]interface{}) (interface{}, error) {
+	resp, err := h.Execute(ctx, functionType, functionName, args, requestContext)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s", resp.Message)
+	}
+	return DecodeResult(resp.Result)
+}
+
+// DecodeResult unwraps an Execute result into the "data" the function returned,
+// see sdk.DecodeExecuteResult
+func DecodeResult(result *anypb.Any) (interface{}, error) {
+	return sdk.DecodeExecuteResult(result)
+}
@@ -0,0 +1,58 @@
+package testutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sdk "github.com/apito-io/go-apito-plugin-sdk"
+)
+
+func TestHostMessageSizeOverride(t *testing.T) {
+	const defaultLimit = 4 * 1024 * 1024
+
+	tests := []struct {
+		name        string
+		maxSize     int
+		resultBytes int
+		wantErr     bool
+	}{
+		{"default limit rejects large result", 0, defaultLimit + 2*1024*1024, true},
+		{"override accepts large result", 2 * defaultLimit, defaultLimit + 2*1024*1024, false},
+		{"override accepts small result", 2 * defaultLimit, 1024, false},
+		{"override still caps larger result", 2 * defaultLimit, 3 * defaultLimit, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := sdk.Init("test-plugin", "1.0.0", "")
+			if tt.maxSize > 0 {
+				p.SetMaxGRPCMessageSize(tt.maxSize)
+			}
+			payload := strings.Repeat("x", tt.resultBytes)
+			p.RegisterFunction("export", sdk.FunctionHandlerFunc(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				return payload, nil
+			}))
+
+			host, err := NewHost(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer host.Close()
+
+			data, err := host.ExecuteAndDecode(context.Background(), "function", "export", nil, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ExecuteAndDecode() succeeded, want a message size error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecuteAndDecode() = %v", err)
+			}
+			if got, _ := data.(string); got != payload {
+				t.Errorf("result has %d bytes, want %d", len(got), len(payload))
+			}
+		})
+	}
+}
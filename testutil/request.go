@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// NewExecuteRequest builds an ExecuteRequest as the host sends it. args become the request
+// arguments and contextData the host context (user_id, tenant_id, ...); either may be nil.
+// Values must be protobuf-compatible: maps, []interface{}, strings, numbers, bools and nil.
+func NewExecuteRequest(functionType, functionName string, args, contextData map[string]interface{}) (*protobuff.ExecuteRequest, error) {
+	req := &protobuff.ExecuteRequest{
+		FunctionName: functionName,
+		FunctionType: functionType,
+	}
+
+	var err error
+	if args != nil {
+		if req.Args, err = structpb.NewStruct(args); err != nil {
+			return nil, fmt.Errorf("invalid args: %v", err)
+		}
+	}
+	if contextData != nil {
+		if req.Context, err = structpb.NewStruct(contextData); err != nil {
+			return nil, fmt.Errorf("invalid context: %v", err)
+		}
+	}
+	return req, nil
+}
+
+// RESTRequest describes a REST call in HTTP terms
+type RESTRequest struct {
+	// Method and Path identify the endpoint as registered, e.g. "GET" and "/users/:id"
+	Method string
+	Path   string
+
+	// PathParams, Query and Body are sent with the ":", "query_" and "body_" prefixes
+	// that GetPathParam, GetQueryParam and GetBodyParam read
+	PathParams map[string]string
+	Query      map[string]interface{}
+	Body       map[string]interface{}
+
+	// Context is the host context (user_id, tenant_id, ...)
+	Context map[string]interface{}
+}
+
+// NewRESTExecuteRequest builds the "rest_api" ExecuteRequest the host sends for r
+func NewRESTExecuteRequest(r RESTRequest) (*protobuff.ExecuteRequest, error) {
+	args := make(map[string]interface{}, len(r.PathParams)+len(r.Query)+len(r.Body))
+	for name, value := range r.PathParams {
+		args[":"+name] = value
+	}
+	for name, value := range r.Query {
+		args["query_"+name] = value
+	}
+	for name, value := range r.Body {
+		args["body_"+name] = value
+	}
+
+	return NewExecuteRequest("rest_api", strings.ToUpper(r.Method)+"_"+r.Path, args, r.Context)
+}
+
+// ExecuteREST calls a REST endpoint on the plugin
+func (h *Host) ExecuteREST(ctx context.Context, r RESTRequest) (*protobuff.ExecuteResponse, error) {
+	req, err := NewRESTExecuteRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.Client.Execute(ctx, req)
+}
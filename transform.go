@@ -0,0 +1,152 @@
+package sdk
+
+import "strings"
+
+// ArgTransformerFunc normalizes a parsed argument value, e.g. trimming or lowercasing a string
+type ArgTransformerFunc func(value interface{}) interface{}
+
+// RegisterArgTransformer registers a transformer applied, after type coercion, to every argument
+// whose base type is name (e.g. "Email" for Email, Email! and [Email]). Execute transforms the
+// arguments before validating them, so constraints and the resolver see the transformed values;
+// ArgParser.ParseArgs applies it as well. An argument can instead name a transformer explicitly
+// with WithTransformer, which takes precedence over the type-based one.
+func (p *Plugin) RegisterArgTransformer(name string, fn ArgTransformerFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.argTransformers == nil {
+		p.argTransformers = make(map[string]ArgTransformerFunc)
+	}
+	p.argTransformers[name] = fn
+}
+
+// WithTransformer makes an argument definition use the transformer registered under name,
+// overriding any transformer registered for its type
+func WithTransformer(arg map[string]interface{}, name string) map[string]interface{} {
+	arg["transform"] = name
+	return arg
+}
+
+// lookupArgTransformer returns the transformer registered under name
+func (p *Plugin) lookupArgTransformer(name string) (ArgTransformerFunc, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	fn, exists := p.argTransformers[name]
+	return fn, exists && fn != nil
+}
+
+// argTransformer returns the transformer for an argument definition: the one named with
+// WithTransformer, or else the one registered for its base type
+func (p *ArgParser) argTransformer(argDef map[string]interface{}) (ArgTransformerFunc, bool) {
+	if p.plugin == nil {
+		return nil, false
+	}
+	name, _ := argDef["transform"].(string)
+	if name == "" {
+		name = strings.Trim(argTypeString(argDef), "[]!")
+	}
+	return p.plugin.lookupArgTransformer(name)
+}
+
+// needsTransform reports whether an argument or any of its object properties has a transformer
+func (p *ArgParser) needsTransform(argDef map[string]interface{}) bool {
+	if _, exists := p.argTransformer(argDef); exists {
+		return true
+	}
+	properties, _ := argDef["properties"].(map[string]interface{})
+	for _, propDef := range properties {
+		if propDefMap, ok := propDef.(map[string]interface{}); ok && p.needsTransform(propDefMap) {
+			return true
+		}
+	}
+	return false
+}
+
+// transformArgs returns rawArgs with every declared argument that has a transformer coerced,
+// transformed and converted back to its JSON form, so the transformed values can be validated
+// and passed to the resolver in the shape raw arguments have. Other arguments are unchanged;
+// rawArgs itself is never modified.
+func (p *ArgParser) transformArgs(rawArgs map[string]interface{}) map[string]interface{} {
+	var transformed map[string]interface{}
+	for argName, argDef := range p.fieldDef.Args {
+		argDefMap, ok := argDef.(map[string]interface{})
+		rawValue, exists := rawArgs[argName]
+		if !ok || !exists || rawValue == nil || !p.needsTransform(argDefMap) {
+			continue
+		}
+		if transformed == nil {
+			transformed = make(map[string]interface{}, len(rawArgs))
+			for key, value := range rawArgs {
+				transformed[key] = value
+			}
+		}
+
+		value := p.parseValue(rawValue, argDefMap)
+		if normalized := toJSONCompatible(value); normalized != nil {
+			value = normalized
+		}
+		transformed[argName] = value
+	}
+
+	if transformed == nil {
+		return rawArgs
+	}
+	return transformed
+}
+
+// transformValue applies the argument's transformer to a coerced value; list values are
+// transformed element by element
+func (p *ArgParser) transformValue(value interface{}, argDef map[string]interface{}) interface{} {
+	if value == nil {
+		return value
+	}
+
+	fn, exists := p.argTransformer(argDef)
+	if !exists {
+		return value
+	}
+	argType := argTypeString(argDef)
+
+	if !strings.HasPrefix(argType, "[") {
+		return fn(value)
+	}
+
+	switch items := value.(type) {
+	case []interface{}:
+		transformed := make([]interface{}, len(items))
+		for i, item := range items {
+			transformed[i] = fn(item)
+		}
+		return transformed
+	case []string:
+		transformed := make([]string, len(items))
+		for i, item := range items {
+			if str, ok := fn(item).(string); ok {
+				transformed[i] = str
+			} else {
+				transformed[i] = item
+			}
+		}
+		return transformed
+	}
+	return value
+}
+
+// Common transformers
+
+// TrimSpaceTransformer trims leading and trailing white space from string values
+func TrimSpaceTransformer(value interface{}) interface{} {
+	if str, ok := value.(string); ok {
+		return strings.TrimSpace(str)
+	}
+	return value
+}
+
+// LowercaseTransformer trims and lowercases string values, e.g. for email addresses
+func LowercaseTransformer(value interface{}) interface{} {
+	if str, ok := value.(string); ok {
+		return strings.ToLower(strings.TrimSpace(str))
+	}
+	return value
+}
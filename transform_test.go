@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apito-io/types/protobuff"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// blankToNil lowercases emails and turns blank ones into null
+func blankToNil(value interface{}) interface{} {
+	if str, ok := value.(string); ok && strings.TrimSpace(str) == "" {
+		return nil
+	}
+	return LowercaseTransformer(value)
+}
+
+func executeWithArgs(t *testing.T, p *Plugin, functionName string, rawArgs map[string]interface{}) *protobuff.ExecuteResponse {
+	t.Helper()
+	args, err := structpb.NewStruct(rawArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.impl.Execute(context.Background(), &protobuff.ExecuteRequest{
+		FunctionType: "graphql_mutation",
+		FunctionName: functionName,
+		Args:         args,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestExecuteTransformsArgsBeforeValidation(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterArgTransformer("Email", blankToNil)
+
+	var received map[string]interface{}
+	p.RegisterMutation("invite", FieldWithArgs("String", "Invite users", map[string]interface{}{
+		"email": Arg("Email", "Primary email"),
+		"cc":    ListArgOf("Email", "Copied emails", NonNullItems()),
+		"note":  StringArg("Note"),
+	}), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		received = args
+		return "ok", nil
+	})
+
+	// Another plugin becoming current must not change which transformers p applies
+	Init("other-plugin", "1.0.0", "")
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "resolver receives transformed values",
+			args: map[string]interface{}{"email": "  Ada@Example.COM ", "cc": []interface{}{"B@X.io"}, "note": " Keep "},
+			want: map[string]interface{}{"email": "ada@example.com", "cc": []interface{}{"b@x.io"}, "note": " Keep "},
+		},
+		{
+			name:    "validation sees transformed values",
+			args:    map[string]interface{}{"cc": []interface{}{"b@x.io", "   "}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			resp := executeWithArgs(t, p, "invite", tt.args)
+			if tt.wantErr {
+				result := &structpb.Struct{}
+				if err := resp.Result.UnmarshalTo(result); err != nil {
+					t.Fatal(err)
+				}
+				if result.AsMap()["is_graphql_error"] != true || received != nil {
+					t.Fatalf("Execute() = %v, resolver got %v; want a validation error", result.AsMap(), received)
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("Execute() = %v", resp)
+			}
+			for key, want := range tt.want {
+				if !reflect.DeepEqual(received[key], want) {
+					t.Errorf("%s = %#v, want %#v", key, received[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDryRunValidatesTransformedArgs(t *testing.T) {
+	p := Init("test-plugin", "1.0.0", "")
+	p.RegisterArgTransformer("Email", blankToNil)
+	p.RegisterMutation("invite", FieldWithArgs("String", "Invite", map[string]interface{}{
+		"cc": ListArgOf("Email", "Copied emails", NonNullItems()),
+	}), func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+
+	resp := executeWithArgs(t, p, DryRunFunctionPrefix+"invite", map[string]interface{}{"cc": []interface{}{"a@x.io", " "}})
+	if !resp.Success {
+		t.Fatalf("Execute() = %v", resp)
+	}
+	result := &structpb.Struct{}
+	if err := resp.Result.UnmarshalTo(result); err != nil {
+		t.Fatal(err)
+	}
+	data := result.AsMap()["data"].(map[string]interface{})
+	if data["valid"] != false {
+		t.Errorf("dry run = %v, want invalid after transforming the blank email to null", data)
+	}
+}
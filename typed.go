@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"context"
+)
+
+// TypedResolverFunc is the function signature for GraphQL resolvers with typed arguments
+type TypedResolverFunc[T any] func(ctx context.Context, args T) (interface{}, error)
+
+// RegisterQueryTyped registers a GraphQL query whose resolver receives its arguments decoded into T.
+// Arguments are bound with BindArgs, so T's json tags control the mapping.
+func RegisterQueryTyped[T any](p *Plugin, name string, field GraphQLField, resolver TypedResolverFunc[T]) {
+	p.RegisterQuery(name, field, typedResolver(resolver))
+}
+
+// RegisterMutationTyped registers a GraphQL mutation whose resolver receives its arguments decoded into T
+func RegisterMutationTyped[T any](p *Plugin, name string, field GraphQLField, resolver TypedResolverFunc[T]) {
+	p.RegisterMutation(name, field, typedResolver(resolver))
+}
+
+// typedResolver adapts a typed resolver to the untyped ResolverFunc signature
+func typedResolver[T any](resolver TypedResolverFunc[T]) ResolverFunc {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		typedArgs, err := decodeArgs[T](args)
+		if err != nil {
+			return nil, err
+		}
+		return resolver(ctx, typedArgs)
+	}
+}
+
+// decodeArgs binds raw arguments into T, returning a BadRequestError when they don't fit
+func decodeArgs[T any](args map[string]interface{}) (T, error) {
+	var typedArgs T
+	err := BindArgs(args, &typedArgs)
+	return typedArgs, err
+}
@@ -0,0 +1,249 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Validate checks the plugin definition for problems the host would otherwise reject at load time
+func (p *Plugin) Validate() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var problems []string
+
+	// Every non-scalar field type must name a registered object type. Fields reference
+	// other object types by name, so self- and mutually-referential types are valid as
+	// long as each referenced type is registered.
+	typeNames := make([]string, 0, len(p.objectTypes))
+	for typeName := range p.objectTypes {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		objectType := p.objectTypes[typeName]
+		fieldNames := make([]string, 0, len(objectType.Fields))
+		for fieldName := range objectType.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		for _, fieldName := range fieldNames {
+			fieldDef := objectType.Fields[fieldName]
+			if fieldDef.Enum {
+				if _, exists := p.enumTypes[fieldDef.Type]; !exists {
+					problems = append(problems, fmt.Sprintf("object type '%s' field '%s' references unregistered enum '%s'", typeName, fieldName, fieldDef.Type))
+				}
+				continue
+			}
+
+			ref := objectTypeReference(fieldDef.Type)
+			if ref == "" {
+				continue
+			}
+			if _, exists := p.objectTypes[ref]; !exists {
+				problems = append(problems, fmt.Sprintf("object type '%s' field '%s' references unregistered type '%s'", typeName, fieldName, ref))
+			}
+		}
+	}
+
+	// Queries, mutations and REST schemas may reference object types by name as well
+	problems = append(problems, p.collectUnregisteredReferences()...)
+
+	// A nil handler would only panic at the first request, so report it at setup time
+	problems = append(problems, p.collectNilHandlers()...)
+
+	// REST schemas are sent to the host as structpb, so every value must convert
+	for _, endpoint := range p.restAPIs {
+		if err := validateRESTSchema(endpoint.Schema); err != nil {
+			problems = append(problems, fmt.Sprintf("REST endpoint %s %s has an invalid schema: %v", endpoint.Method, endpoint.Path, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("plugin '%s' has %d invalid definition(s): %s", p.name, len(problems), strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// objectTypeReference returns the object type name referenced by a field type, or an empty
+// string when the type is a scalar or a JSON passthrough type that needs no registration
+func objectTypeReference(fieldType string) string {
+	typeName := strings.Trim(fieldType, "[]!")
+	if typeName == "" || isScalarType(typeName) || typeName == "Object" || strings.HasPrefix(typeName, "JSON") {
+		return ""
+	}
+	return typeName
+}
+
+// jsonSchemaTypes are the primitive type names used by REST schemas
+var jsonSchemaTypes = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"integer": true,
+	"number":  true,
+	"boolean": true,
+	"null":    true,
+}
+
+// collectUnregisteredReferences walks query, mutation and REST schema definitions and
+// describes every referenced object type name that is not registered
+func (p *Plugin) collectUnregisteredReferences() []string {
+	var problems []string
+
+	report := func(owner string, refs map[string]bool) {
+		names := make([]string, 0, len(refs))
+		for name := range refs {
+			if _, exists := p.objectTypes[name]; !exists {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			problems = append(problems, fmt.Sprintf("%s references unregistered type '%s'", owner, name))
+		}
+	}
+
+	for _, kind := range []struct {
+		label  string
+		fields map[string]GraphQLField
+	}{{"query", p.queries}, {"mutation", p.mutations}} {
+		names := make([]string, 0, len(kind.fields))
+		for name := range kind.fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			field := kind.fields[name]
+			refs := make(map[string]bool)
+			collectTypeReferences(field.Type, refs)
+			collectTypeReferences(field.Args, refs)
+			report(fmt.Sprintf("%s '%s'", kind.label, name), refs)
+		}
+	}
+
+	for _, endpoint := range p.restAPIs {
+		refs := make(map[string]bool)
+		collectTypeReferences(endpoint.Schema, refs)
+		report(fmt.Sprintf("REST endpoint %s %s", endpoint.Method, endpoint.Path), refs)
+	}
+
+	return problems
+}
+
+// collectNilHandlers reports resolvers, REST handlers and functions registered as nil
+func (p *Plugin) collectNilHandlers() []string {
+	var problems []string
+	for name, resolver := range p.resolvers {
+		if resolver == nil {
+			problems = append(problems, fmt.Sprintf("resolver '%s' is nil", name))
+		}
+	}
+	for _, endpoint := range p.restAPIs {
+		if p.restHandlers[endpoint.Handler] == nil {
+			problems = append(problems, fmt.Sprintf("REST endpoint %s %s has a nil handler", endpoint.Method, endpoint.Path))
+		}
+	}
+	for name, function := range p.functions {
+		if function == nil {
+			problems = append(problems, fmt.Sprintf("function '%s' is nil", name))
+		}
+	}
+	for name, resolver := range p.fieldResolvers {
+		if resolver == nil {
+			problems = append(problems, fmt.Sprintf("field resolver '%s' is nil", name))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// validateRESTSchema reports the first value in a REST schema that structpb can't represent,
+// naming its key path (e.g. "request.properties.tags")
+func validateRESTSchema(schema map[string]interface{}) error {
+	return validateSchemaValue("", schema)
+}
+
+// validateSchemaValue walks a schema value, descending into maps and slices
+func validateSchemaValue(path string, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if err := validateSchemaValue(childPath, v[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []interface{}:
+		for i, item := range v {
+			if err := validateSchemaValue(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := structpb.NewValue(value); err != nil {
+		return fmt.Errorf("key '%s': unsupported value of type %T", path, value)
+	}
+	return nil
+}
+
+// collectTypeReferences records the object type names referenced anywhere inside a
+// GraphQL type definition, argument map or REST schema
+func collectTypeReferences(value interface{}, refs map[string]bool) {
+	switch v := value.(type) {
+	case GraphQLField:
+		collectTypeReferences(v.Type, refs)
+		collectTypeReferences(v.Args, refs)
+
+	case GraphQLTypeDefinition:
+		if v.Kind == "object" && v.Name != "" {
+			if ref := objectTypeReference(v.Name); ref != "" {
+				refs[ref] = true
+			}
+		}
+		if v.OfType != nil {
+			collectTypeReferences(*v.OfType, refs)
+		}
+		collectTypeReferences(v.Fields, refs)
+
+	case map[string]interface{}:
+		for key, val := range v {
+			// Example payloads are user data, not type definitions
+			if key == "examples" {
+				continue
+			}
+			typeName, isString := val.(string)
+			if isString && (key == "type" || key == "objectType") && !jsonSchemaTypes[typeName] {
+				if ref := objectTypeReference(typeName); ref != "" {
+					refs[ref] = true
+				}
+				continue
+			}
+			collectTypeReferences(val, refs)
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			collectTypeReferences(item, refs)
+		}
+	}
+}
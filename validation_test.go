@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateReferentialObjectTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() []ObjectTypeDefinition
+		refs    map[string]string // "Type.field" -> serialized field type
+		wantErr string
+	}{
+		{
+			name: "self-referential",
+			build: func() []ObjectTypeDefinition {
+				return []ObjectTypeDefinition{
+					NewObjectType("Category", "A category").
+						AddIDField("id", "ID", false).
+						AddSelfReferenceField("parent", "Parent category", true).
+						AddSelfReferenceListField("children", "Child categories", false, true).
+						Build(),
+				}
+			},
+			refs: map[string]string{"Category.parent": "Category", "Category.children": "[Category!]!"},
+		},
+		{
+			name: "mutually referential",
+			build: func() []ObjectTypeDefinition {
+				return []ObjectTypeDefinition{
+					NewObjectType("Author", "An author").
+						AddIDField("id", "ID", false).
+						AddObjectListField("books", "Books", "Book", false, true).
+						Build(),
+					NewObjectType("Book", "A book").
+						AddIDField("id", "ID", false).
+						AddObjectField("author", "Author", "Author", false).
+						Build(),
+				}
+			},
+			refs: map[string]string{"Author.books": "[Book!]!", "Book.author": "Author!"},
+		},
+		{
+			name: "unregistered reference",
+			build: func() []ObjectTypeDefinition {
+				return []ObjectTypeDefinition{
+					NewObjectType("Review", "A review").AddObjectField("book", "Book", "Book", true).Build(),
+				}
+			},
+			wantErr: "references unregistered type 'Book'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Init("test-plugin", "1.0.0", "")
+			defs := tt.build()
+
+			err := p.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+
+			checkedRefs := 0
+			for _, def := range defs {
+				fields := p.impl.serializeObjectTypeDefinition(def)["fields"].(map[string]interface{})
+				for fieldName, field := range fields {
+					want, checked := tt.refs[def.TypeName+"."+fieldName]
+					if !checked {
+						continue
+					}
+					checkedRefs++
+					if got := typeDefinitionString(field.(map[string]interface{})["type"]); got != want {
+						t.Errorf("%s.%s type = %q, want %q", def.TypeName, fieldName, got, want)
+					}
+				}
+			}
+			if checkedRefs != len(tt.refs) {
+				t.Errorf("checked %d reference fields, want %d", checkedRefs, len(tt.refs))
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HashAlgo selects the hash function of an HMAC webhook signature
+type HashAlgo string
+
+const (
+	HashSHA256 HashAlgo = "sha256"
+	HashSHA1   HashAlgo = "sha1"
+)
+
+// newHash returns the hash constructor for the algorithm
+func (a HashAlgo) newHash() (func() hash.Hash, bool) {
+	switch a {
+	case HashSHA256:
+		return sha256.New, true
+	case HashSHA1:
+		return sha1.New, true
+	}
+	return nil, false
+}
+
+// VerifyHMACSignature checks that signatureHeader is the HMAC of rawBody (see GetRawBody) with
+// secret. The signature may be hex or base64 and may carry an "<algo>=" prefix, as in GitHub's
+// "sha256=...". Comparison is constant-time; a mismatch returns an UnauthorizedError.
+func VerifyHMACSignature(rawBody []byte, signatureHeader, secret string, algo HashAlgo) error {
+	newHash, ok := algo.newHash()
+	if !ok {
+		return InternalServerError("Unsupported webhook signature algorithm", string(algo))
+	}
+	if secret == "" {
+		return InternalServerError("Webhook secret is not configured")
+	}
+
+	signature := strings.TrimSpace(signatureHeader)
+	signature = strings.TrimPrefix(signature, string(algo)+"=")
+	if signature == "" {
+		return UnauthorizedError("Missing webhook signature")
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(rawBody)
+	if !signatureMatches(mac.Sum(nil), signature) {
+		return UnauthorizedError("Invalid webhook signature")
+	}
+	return nil
+}
+
+// VerifyGitHubSignature verifies a GitHub webhook from its X-Hub-Signature-256 header
+func VerifyGitHubSignature(rawBody []byte, signatureHeader, secret string) error {
+	if !strings.HasPrefix(signatureHeader, "sha256=") {
+		return UnauthorizedError("Invalid webhook signature", "expected a sha256= signature")
+	}
+	return VerifyHMACSignature(rawBody, signatureHeader, secret, HashSHA256)
+}
+
+// DefaultStripeTolerance is the largest accepted age of a Stripe webhook timestamp
+const DefaultStripeTolerance = 5 * time.Minute
+
+// VerifyStripeSignature verifies a Stripe webhook from its Stripe-Signature header
+// ("t=<unix>,v1=<hex>[,v1=<hex>...]"). Events older than tolerance are rejected to prevent
+// replays; a zero tolerance uses DefaultStripeTolerance.
+func VerifyStripeSignature(rawBody []byte, signatureHeader, secret string, tolerance time.Duration) error {
+	if secret == "" {
+		return InternalServerError("Webhook secret is not configured")
+	}
+	if tolerance == 0 {
+		tolerance = DefaultStripeTolerance
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signatureHeader, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return UnauthorizedError("Invalid webhook signature", "malformed Stripe-Signature header")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return UnauthorizedError("Invalid webhook signature", "malformed timestamp")
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return UnauthorizedError("Invalid webhook signature", "timestamp outside the tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(rawBody)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		if signatureMatches(expected, signature) {
+			return nil
+		}
+	}
+	return UnauthorizedError("Invalid webhook signature")
+}
+
+// signatureMatches compares an expected MAC with a hex- or base64-encoded signature in constant time
+func signatureMatches(expected []byte, signature string) bool {
+	if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(expected, decoded) {
+		return true
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil && hmac.Equal(expected, decoded) {
+		return true
+	}
+	return false
+}